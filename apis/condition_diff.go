@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+// ConditionChange describes how the condition of one Type differs between
+// two Conditions. Old is nil for a condition that was added and New is nil
+// for one that was removed.
+type ConditionChange struct {
+	Type ConditionType
+	Old  *Condition
+	New  *Condition
+}
+
+// StatusChanged is true if the condition transitioned, i.e. its status
+// differs, including when it was added or removed.
+func (cc ConditionChange) StatusChanged() bool {
+	return cc.Old == nil || cc.New == nil || cc.Old.Status != cc.New.Status
+}
+
+// DiffConditions pairs the conditions in old and new by Type and returns a
+// change for every Type whose condition was added, removed or differs in
+// anything but its LastTransitionTime. Changes to conditions present in old
+// come first, in the order of old, followed by the added ones in the order
+// of new.
+func DiffConditions(old, new Conditions) []ConditionChange {
+	var changes []ConditionChange
+	for i := range old {
+		o := &old[i]
+		n := findCondition(new, o.Type)
+		if !o.EqualIgnoringTime(n) {
+			changes = append(changes, ConditionChange{Type: o.Type, Old: o, New: n})
+		}
+	}
+	for i := range new {
+		n := &new[i]
+		if findCondition(old, n.Type) == nil {
+			changes = append(changes, ConditionChange{Type: n.Type, New: n})
+		}
+	}
+	return changes
+}
+
+func findCondition(conds Conditions, t ConditionType) *Condition {
+	for i := range conds {
+		if conds[i].Type == t {
+			return &conds[i]
+		}
+	}
+	return nil
+}
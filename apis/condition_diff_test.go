@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiffConditions(t *testing.T) {
+	now := VolatileTime{Inner: metav1.NewTime(time.Now())}
+	ready := Condition{Type: ConditionReady, Status: corev1.ConditionTrue}
+	readyLater := ready
+	readyLater.LastTransitionTime = now
+	notReady := Condition{Type: ConditionReady, Status: corev1.ConditionFalse, Reason: "Broken", Message: "it broke"}
+	foo := Condition{Type: "Foo", Status: corev1.ConditionUnknown, Reason: "Waiting"}
+	fooReason := foo
+	fooReason.Reason = "StillWaiting"
+	fooMessage := foo
+	fooMessage.Message = "still waiting"
+	bar := Condition{Type: "Bar", Status: corev1.ConditionTrue}
+
+	tests := []struct {
+		name string
+		old  Conditions
+		new  Conditions
+		want []ConditionChange
+	}{{
+		name: "both empty",
+	}, {
+		name: "unchanged",
+		old:  Conditions{ready, foo},
+		new:  Conditions{foo, ready},
+	}, {
+		name: "only time changed",
+		old:  Conditions{ready},
+		new:  Conditions{readyLater},
+	}, {
+		name: "added",
+		old:  Conditions{ready},
+		new:  Conditions{ready, bar},
+		want: []ConditionChange{{Type: "Bar", New: &bar}},
+	}, {
+		name: "removed",
+		old:  Conditions{ready, bar},
+		new:  Conditions{ready},
+		want: []ConditionChange{{Type: "Bar", Old: &bar}},
+	}, {
+		name: "status changed",
+		old:  Conditions{ready},
+		new:  Conditions{notReady},
+		want: []ConditionChange{{Type: ConditionReady, Old: &ready, New: &notReady}},
+	}, {
+		name: "reason and message changed",
+		old:  Conditions{foo, bar},
+		new:  Conditions{fooReason, bar},
+		want: []ConditionChange{{Type: "Foo", Old: &foo, New: &fooReason}},
+	}, {
+		name: "all kinds",
+		old:  Conditions{ready, fooMessage, bar},
+		new:  Conditions{foo, notReady},
+		want: []ConditionChange{
+			{Type: ConditionReady, Old: &ready, New: &notReady},
+			{Type: "Foo", Old: &fooMessage, New: &foo},
+			{Type: "Bar", Old: &bar},
+		},
+	}, {
+		name: "all added",
+		new:  Conditions{bar, foo},
+		want: []ConditionChange{{Type: "Bar", New: &bar}, {Type: "Foo", New: &foo}},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := DiffConditions(test.old, test.new)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error("DiffConditions() (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestConditionChangeStatusChanged(t *testing.T) {
+	ready := &Condition{Type: ConditionReady, Status: corev1.ConditionTrue}
+	readyReason := &Condition{Type: ConditionReady, Status: corev1.ConditionTrue, Reason: "Again"}
+	notReady := &Condition{Type: ConditionReady, Status: corev1.ConditionFalse}
+
+	tests := []struct {
+		name   string
+		change ConditionChange
+		want   bool
+	}{{
+		name:   "added",
+		change: ConditionChange{Type: ConditionReady, New: ready},
+		want:   true,
+	}, {
+		name:   "removed",
+		change: ConditionChange{Type: ConditionReady, Old: ready},
+		want:   true,
+	}, {
+		name:   "transitioned",
+		change: ConditionChange{Type: ConditionReady, Old: ready, New: notReady},
+		want:   true,
+	}, {
+		name:   "same status",
+		change: ConditionChange{Type: ConditionReady, Old: ready, New: readyReason},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.change.StatusChanged(); got != test.want {
+				t.Errorf("StatusChanged() = %v, want: %v", got, test.want)
+			}
+		})
+	}
+}
@@ -17,14 +17,15 @@ limitations under the License.
 package apis
 
 import (
-	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // Conditions is the interface for a Resource that implements the getter and
@@ -35,6 +36,22 @@ type ConditionsAccessor interface {
 	SetConditions(Conditions)
 }
 
+// ObservedGenerationAccessor is implemented by statuses that record the
+// generation of the spec they reflect, e.g. duckv1.Status.
+type ObservedGenerationAccessor interface {
+	GetObservedGeneration() int64
+	SetObservedGeneration(int64)
+}
+
+// TransitionCountAccessor is implemented by statuses that count how often
+// each of their conditions changed status, e.g. to detect flapping. Where
+// the counts are kept, e.g. in an annotation, is up to the status; tracking
+// is opt-in and does not change the Condition schema.
+type TransitionCountAccessor interface {
+	GetTransitionCount(t ConditionType) int
+	SetTransitionCount(t ConditionType, count int)
+}
+
 // ConditionAccessor is used to access a condition through it's type
 type ConditionAccessor interface {
 	// GetCondition finds and returns the Condition that matches the ConditionType
@@ -50,6 +67,14 @@ type ConditionAccessor interface {
 type ConditionSet struct {
 	happy      ConditionType
 	dependents []ConditionType
+	happyFirst bool
+	// reasons holds the known reasons per ConditionType, see WithReasons.
+	reasons       map[ConditionType][]string
+	strictReasons bool
+	// onUnknownReason is told about writes rejected in strict mode.
+	onUnknownReason func(error)
+	// problems describes the mistakes made creating the set, see Validate.
+	problems []string
 }
 
 // ConditionManager allows a resource to operate on its Conditions using higher
@@ -64,10 +89,26 @@ type ConditionManager interface {
 	// GetTopLevelCondition finds and returns the top level Condition (happy Condition).
 	GetTopLevelCondition() *Condition
 
+	// HappyReasonMessage returns the reason and message of the happy
+	// condition while it is not True, and empty strings otherwise.
+	HappyReasonMessage() (reason, message string)
+
+	// HasFailedDependent returns the first dependent Condition that is False,
+	// and whether there is one.
+	HasFailedDependent() (*Condition, bool)
+
+	// BlockingDependents returns the dependent Conditions that are not True,
+	// sorted by type.
+	BlockingDependents() []Condition
+
 	// SetCondition sets or updates the Condition on Conditions for Condition.Type.
 	// If there is an update, Conditions are stored back sorted.
 	SetCondition(new Condition)
 
+	// UpdateCondition fetches the Condition for t (or a new one when it is not
+	// set yet), lets mutate change it and stores the result via SetCondition.
+	UpdateCondition(t ConditionType, mutate func(*Condition))
+
 	// ClearCondition removes the non terminal condition that matches the ConditionType
 	ClearCondition(t ConditionType) error
 
@@ -86,41 +127,109 @@ type ConditionManager interface {
 	// MarkFalse sets the status of t and the happy condition to False.
 	MarkFalse(t ConditionType, reason, messageFormat string, messageA ...interface{})
 
+	// MarkFalseMany sets the status of each of the given types to False with
+	// the same reason and message, and the happy condition to False once if any
+	// of them is a dependent.
+	MarkFalseMany(reason, message string, types ...ConditionType)
+
+	// MarkHappyTrue forces the happy condition to True with the given reason
+	// and message, regardless of the dependents.
+	MarkHappyTrue(reason, message string)
+
+	// MarkDependencyNotReady sets the happy condition to Unknown, citing the
+	// first dependent that is Unknown or not set. If a dependent is False the
+	// happy condition is set to False instead.
+	MarkDependencyNotReady()
+
+	// MarkReconciling sets the happy condition to Unknown to signal that the
+	// resource is being reconciled.
+	MarkReconciling(reason, messageFormat string, messageA ...interface{})
+
+	// MarkFailed sets the happy condition to False to signal that the resource
+	// failed terminally.
+	MarkFailed(reason, messageFormat string, messageA ...interface{})
+
 	// InitializeConditions updates all Conditions in the ConditionSet to Unknown
 	// if not set.
 	InitializeConditions()
+
+	// ResetConditions sets the happy condition and all dependents to Unknown
+	// with the given reason and message, whatever their status.
+	ResetConditions(reason, message string)
+
+	// MarkObservedGeneration records generation as the observed generation,
+	// if the status implements ObservedGenerationAccessor.
+	MarkObservedGeneration(generation int64)
+
+	// IsCurrent returns true if the status implements
+	// ObservedGenerationAccessor and has observed generation.
+	IsCurrent(generation int64) bool
+
+	// IsCurrentAndHappy returns true if the status has observed generation
+	// and the happy condition is true.
+	IsCurrentAndHappy(generation int64) bool
+
+	// TransitionCount returns how often the status of the condition of type
+	// t changed, if the status implements TransitionCountAccessor.
+	TransitionCount(t ConditionType) int
 }
 
 // NewLivingConditionSet returns a ConditionSet to hold the conditions for the
 // living resource. ConditionReady is used as the happy condition.
 // The set of condition types provided are those of the terminal subconditions.
 func NewLivingConditionSet(d ...ConditionType) ConditionSet {
-	return newConditionSet(ConditionReady, d...)
+	return NewConditionSet(ConditionReady, d...)
 }
 
 // NewBatchConditionSet returns a ConditionSet to hold the conditions for the
 // batch resource. ConditionSucceeded is used as the happy condition.
 // The set of condition types provided are those of the terminal subconditions.
 func NewBatchConditionSet(d ...ConditionType) ConditionSet {
-	return newConditionSet(ConditionSucceeded, d...)
+	return NewConditionSet(ConditionSucceeded, d...)
 }
 
-// newConditionSet returns a ConditionSet to hold the conditions that are
+// NewConditionSet returns a ConditionSet to hold the conditions that are
 // important for the caller. The first ConditionType is the overarching status
-// for that will be used to signal the resources' status is Ready or Succeeded.
-func newConditionSet(happy ConditionType, dependents ...ConditionType) ConditionSet {
+// for that will be used to signal the resources' status is Ready or Succeeded,
+// or any other happy condition the resource chooses to expose.
+// Dependents that repeat or match the happy condition are skipped;
+// Validate reports these and other authoring mistakes.
+func NewConditionSet(happy ConditionType, dependents ...ConditionType) ConditionSet {
+	var problems []string
+	if happy == "" {
+		problems = append(problems, "the happy condition type is empty")
+	}
 	deps := make([]ConditionType, 0, len(dependents))
 	for _, d := range dependents {
 		// Skip duplicates
-		if d == happy || contains(deps, d) {
+		switch {
+		case d == happy:
+			problems = append(problems, fmt.Sprintf("dependent %q is the happy condition", d))
+			continue
+		case contains(deps, d):
+			problems = append(problems, fmt.Sprintf("dependent %q is listed more than once", d))
 			continue
+		case d == "":
+			problems = append(problems, "a dependent condition type is empty")
 		}
 		deps = append(deps, d)
 	}
 	return ConditionSet{
 		happy:      happy,
 		dependents: deps,
+		problems:   problems,
+	}
+}
+
+// Validate returns an error describing the mistakes made when the
+// ConditionSet was created, e.g. passing the happy condition or the same
+// dependent twice, or nil if there were none. The ConditionSet itself works
+// around those mistakes, so this is meant to be called from tests.
+func (r ConditionSet) Validate() error {
+	if len(r.problems) == 0 {
+		return nil
 	}
+	return fmt.Errorf("invalid ConditionSet for %q: %s", r.happy, strings.Join(r.problems, ", "))
 }
 
 func contains(ct []ConditionType, t ConditionType) bool {
@@ -140,6 +249,16 @@ var _ ConditionManager = (*conditionsImpl)(nil)
 type conditionsImpl struct {
 	ConditionSet
 	accessor ConditionsAccessor
+
+	// recorder, when set, is told about transitions of object's conditions.
+	recorder EventRecorder
+	object   runtime.Object
+}
+
+// EventRecorder records Kubernetes Events about an object. It is the subset
+// of k8s.io/client-go/tools/record.EventRecorder used by ManageWithRecorder.
+type EventRecorder interface {
+	Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{})
 }
 
 // GetTopLevelConditionType is an accessor for the top-level happy condition.
@@ -147,6 +266,69 @@ func (r ConditionSet) GetTopLevelConditionType() ConditionType {
 	return r.happy
 }
 
+// GetDependentConditionTypes returns the dependent (terminal) condition types
+// that roll up into the happy condition. The returned slice is a copy and may
+// be modified by the caller.
+func (r ConditionSet) GetDependentConditionTypes() []ConditionType {
+	deps := make([]ConditionType, len(r.dependents))
+	copy(deps, r.dependents)
+	return deps
+}
+
+// WithHappyFirst returns a copy of the ConditionSet whose managers store the
+// happy condition ahead of all other conditions, which are still sorted by
+// type. By default every condition, including the happy one, is sorted by type.
+func (r ConditionSet) WithHappyFirst() ConditionSet {
+	r.happyFirst = true
+	return r
+}
+
+// WithReasons returns a copy of the ConditionSet that knows reasons as the
+// valid reasons for conditions of type t, in addition to any registered
+// before. Types without registered reasons accept any reason.
+func (r ConditionSet) WithReasons(t ConditionType, reasons ...string) ConditionSet {
+	known := make(map[ConditionType][]string, len(r.reasons)+1)
+	for k, v := range r.reasons {
+		known[k] = v
+	}
+	known[t] = append(append([]string(nil), known[t]...), reasons...)
+	r.reasons = known
+	return r
+}
+
+// WithStrictReasons returns a copy of the ConditionSet whose managers reject
+// SetCondition calls that store a Condition with a reason that IsKnownReason
+// rejects. The Condition is left untouched and onUnknown, when non-nil, is
+// called with an error describing the rejected write, e.g. to fail a test.
+func (r ConditionSet) WithStrictReasons(onUnknown func(error)) ConditionSet {
+	r.strictReasons = true
+	r.onUnknownReason = onUnknown
+	return r
+}
+
+// IsKnownReason returns true if reason may be used for conditions of type t.
+// That is the case when reason is empty, no reasons were registered for t,
+// or reason was registered for t with WithReasons. Since the happy condition
+// mirrors the reasons of its dependents, it also accepts the reasons
+// registered for any dependent.
+func (r ConditionSet) IsKnownReason(t ConditionType, reason string) bool {
+	if reason == "" {
+		return true
+	}
+	known, ok := r.reasons[t]
+	if !ok || containsString(known, reason) {
+		return true
+	}
+	if t == r.happy {
+		for _, d := range r.dependents {
+			if containsString(r.reasons[d], reason) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Manage creates a ConditionManager from an accessor object using the original
 // ConditionSet as a reference. Status must be a pointer to a struct.
 func (r ConditionSet) Manage(status ConditionsAccessor) ConditionManager {
@@ -156,6 +338,45 @@ func (r ConditionSet) Manage(status ConditionsAccessor) ConditionManager {
 	}
 }
 
+// Summarize returns a one-word summary of the conditions of status, e.g. for
+// an additionalPrinterColumn: the happy condition type, e.g. "Ready", when
+// it is True; the reason of the first failed dependent, or else of the
+// happy condition, when it is False; and "Reconciling" otherwise. A failure
+// without a reason is summarized as "Failed".
+func (r ConditionSet) Summarize(status ConditionsAccessor) string {
+	m := r.Manage(status)
+	happy := m.GetTopLevelCondition()
+	switch {
+	case happy.IsTrue():
+		return string(r.happy)
+	case happy.IsFalse():
+		reason := happy.Reason
+		if c, ok := m.HasFailedDependent(); ok && c.Reason != "" {
+			reason = c.Reason
+		}
+		if reason == "" {
+			return "Failed"
+		}
+		return reason
+	default:
+		return "Reconciling"
+	}
+}
+
+// ManageWithRecorder is like Manage, but the returned ConditionManager also
+// emits an Event about object on recorder whenever SetCondition, directly or
+// through one of the Mark methods, changes the status of a condition to True
+// (Normal) or False (Warning). Updates that keep the status, and conditions
+// becoming Unknown, do not emit.
+func (r ConditionSet) ManageWithRecorder(status ConditionsAccessor, recorder EventRecorder, object runtime.Object) ConditionManager {
+	return conditionsImpl{
+		accessor:     status,
+		ConditionSet: r,
+		recorder:     recorder,
+		object:       object,
+	}
+}
+
 // IsHappy looks at the top level Condition (happy Condition) and returns true if that condition is
 // set to true.
 func (r conditionsImpl) IsHappy() bool {
@@ -167,8 +388,52 @@ func (r conditionsImpl) GetTopLevelCondition() *Condition {
 	return r.GetCondition(r.happy)
 }
 
+// HappyReasonMessage returns the reason and message of the happy condition
+// while it is Unknown or False, i.e. why the resource is not happy. For a
+// True or absent happy condition both are empty.
+func (r conditionsImpl) HappyReasonMessage() (reason, message string) {
+	c := r.GetTopLevelCondition()
+	if c == nil || c.IsTrue() {
+		return "", ""
+	}
+	return c.Reason, c.Message
+}
+
+// HasFailedDependent returns the first dependent Condition, in the order the
+// dependents were declared, that is False, and whether there is one. Unlike
+// IsHappy it tells a failed resource apart from one that is not ready yet.
+func (r conditionsImpl) HasFailedDependent() (*Condition, bool) {
+	for _, t := range r.dependents {
+		if c := r.GetCondition(t); c.IsFalse() {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// BlockingDependents returns the dependent Conditions that keep the happy
+// condition from being True, i.e. those that are Unknown or False, sorted by
+// type. A dependent that is not set yet is returned as an Unknown Condition
+// of its type.
+func (r conditionsImpl) BlockingDependents() []Condition {
+	var blocking []Condition
+	for _, t := range r.dependents {
+		c := r.GetCondition(t)
+		switch {
+		case c == nil:
+			blocking = append(blocking, Condition{Type: t, Status: corev1.ConditionUnknown})
+		case !c.IsTrue():
+			blocking = append(blocking, *c)
+		}
+	}
+	sort.Slice(blocking, func(i, j int) bool { return blocking[i].Type < blocking[j].Type })
+	return blocking
+}
+
 // GetCondition finds and returns the Condition that matches the ConditionType
 // previously set on Conditions.
+// The returned Condition is a copy, changes to it are not stored back, use
+// UpdateCondition for that.
 func (r conditionsImpl) GetCondition(t ConditionType) *Condition {
 	if r.accessor == nil {
 		return nil
@@ -185,27 +450,109 @@ func (r conditionsImpl) GetCondition(t ConditionType) *Condition {
 // SetCondition sets or updates the Condition on Conditions for Condition.Type.
 // If there is an update, Conditions are stored back sorted.
 func (r conditionsImpl) SetCondition(cond Condition) {
+	r.setCondition(cond)
+}
+
+// setCondition implements SetCondition and returns false when the write was
+// rejected because of an unknown reason, see WithStrictReasons.
+func (r conditionsImpl) setCondition(cond Condition) bool {
 	if r.accessor == nil {
-		return
+		return true
+	}
+	if r.strictReasons && !r.IsKnownReason(cond.Type, cond.Reason) {
+		if r.onUnknownReason != nil {
+			r.onUnknownReason(fmt.Errorf("unknown reason %q for condition %q", cond.Reason, cond.Type))
+		}
+		return false
 	}
 	t := cond.Type
 	var conditions Conditions
+	var (
+		oldStatus corev1.ConditionStatus
+		found     bool
+	)
 	for _, c := range r.accessor.GetConditions() {
 		if c.Type != t {
 			conditions = append(conditions, c)
 		} else {
 			// If we'd only update the LastTransitionTime, then return.
-			cond.LastTransitionTime = c.LastTransitionTime
-			if reflect.DeepEqual(cond, c) {
-				return
+			if cond.EqualIgnoringTime(&c) {
+				return true
 			}
+			oldStatus, found = c.Status, true
 		}
 	}
 	cond.LastTransitionTime = VolatileTime{Inner: metav1.NewTime(time.Now())}
 	conditions = append(conditions, cond)
-	// Sorted for convenience of the consumer, i.e. kubectl.
-	sort.Slice(conditions, func(i, j int) bool { return conditions[i].Type < conditions[j].Type })
+	r.sortConditions(conditions)
 	r.accessor.SetConditions(conditions)
+	if cond.Status != oldStatus {
+		r.recordTransition(cond)
+		if tca, ok := r.accessor.(TransitionCountAccessor); ok && found {
+			tca.SetTransitionCount(t, tca.GetTransitionCount(t)+1)
+		}
+	}
+	return true
+}
+
+// recordTransition emits an Event for cond, which just transitioned to its
+// status, if there is a recorder and the status is True or False.
+func (r conditionsImpl) recordTransition(cond Condition) {
+	if r.recorder == nil {
+		return
+	}
+	var eventtype string
+	switch cond.Status {
+	case corev1.ConditionTrue:
+		eventtype = corev1.EventTypeNormal
+	case corev1.ConditionFalse:
+		eventtype = corev1.EventTypeWarning
+	default:
+		return
+	}
+	reason, message := cond.Reason, cond.Message
+	if reason == "" {
+		reason = string(cond.Type) + string(cond.Status)
+	}
+	if message == "" {
+		message = fmt.Sprintf("%s is %s", cond.Type, cond.Status)
+	}
+	r.recorder.Eventf(r.object, eventtype, reason, "%s", message)
+}
+
+// sortConditions sorts the conditions for convenience of the consumer,
+// i.e. kubectl.
+func (r conditionsImpl) sortConditions(conditions Conditions) {
+	sort.Slice(conditions, func(i, j int) bool {
+		if r.happyFirst && conditions[i].Type != conditions[j].Type {
+			if conditions[i].Type == r.happy {
+				return true
+			}
+			if conditions[j].Type == r.happy {
+				return false
+			}
+		}
+		return conditions[i].Type < conditions[j].Type
+	})
+}
+
+// UpdateCondition fetches the Condition for t (or a new one when it is not
+// set yet), lets mutate change it and stores the result via SetCondition.
+// The Type of the Condition cannot be changed by mutate.
+func (r conditionsImpl) UpdateCondition(t ConditionType, mutate func(*Condition)) {
+	if r.accessor == nil {
+		return
+	}
+	cond := r.GetCondition(t)
+	if cond == nil {
+		cond = &Condition{
+			Type:     t,
+			Severity: r.severity(t),
+		}
+	}
+	mutate(cond)
+	cond.Type = t
+	r.SetCondition(*cond)
 }
 
 func (r conditionsImpl) isTerminal(t ConditionType) bool {
@@ -246,9 +593,12 @@ func (r conditionsImpl) ClearCondition(t ConditionType) error {
 		}
 	}
 
-	// Sorted for convenience of the consumer, i.e. kubectl.
-	sort.Slice(conditions, func(i, j int) bool { return conditions[i].Type < conditions[j].Type })
+	r.sortConditions(conditions)
 	r.accessor.SetConditions(conditions)
+	// A condition that is set again starts counting from scratch.
+	if tca, ok := r.accessor.(TransitionCountAccessor); ok {
+		tca.SetTransitionCount(t, 0)
+	}
 
 	return nil
 }
@@ -269,13 +619,15 @@ func (r conditionsImpl) MarkTrue(t ConditionType) {
 // true if all other dependents are also true.
 func (r conditionsImpl) MarkTrueWithReason(t ConditionType, reason, messageFormat string, messageA ...interface{}) {
 	// set the specified condition
-	r.SetCondition(Condition{
+	if !r.setCondition(Condition{
 		Type:     t,
 		Status:   corev1.ConditionTrue,
 		Reason:   reason,
 		Message:  fmt.Sprintf(messageFormat, messageA...),
 		Severity: r.severity(t),
-	})
+	}) {
+		return
+	}
 	r.recomputeHappiness(t)
 }
 
@@ -353,13 +705,15 @@ func (r conditionsImpl) findUnhappyDependent() *Condition {
 // to Unknown if no other dependent condition is in an error state.
 func (r conditionsImpl) MarkUnknown(t ConditionType, reason, messageFormat string, messageA ...interface{}) {
 	// set the specified condition
-	r.SetCondition(Condition{
+	if !r.setCondition(Condition{
 		Type:     t,
 		Status:   corev1.ConditionUnknown,
 		Reason:   reason,
 		Message:  fmt.Sprintf(messageFormat, messageA...),
 		Severity: r.severity(t),
-	})
+	}) {
+		return
+	}
 
 	// check the dependents.
 	isDependent := false
@@ -401,16 +755,125 @@ func (r conditionsImpl) MarkFalse(t ConditionType, reason, messageFormat string,
 	}
 
 	for _, t := range types {
-		r.SetCondition(Condition{
+		if !r.setCondition(Condition{
 			Type:     t,
 			Status:   corev1.ConditionFalse,
 			Reason:   reason,
 			Message:  fmt.Sprintf(messageFormat, messageA...),
 			Severity: r.severity(t),
+		}) {
+			// Don't mirror a rejected reason on the happy condition.
+			return
+		}
+	}
+}
+
+// MarkFalseMany sets the status of each of the given types to False with the
+// same reason and message, and the happy condition to False once if any of
+// them is a dependent.
+func (r conditionsImpl) MarkFalseMany(reason, message string, types ...ConditionType) {
+	markHappy := false
+	for _, t := range types {
+		if t == r.happy {
+			markHappy = true
+			continue
+		}
+		if contains(r.dependents, t) {
+			markHappy = true
+		}
+		if !r.setCondition(Condition{
+			Type:     t,
+			Status:   corev1.ConditionFalse,
+			Reason:   reason,
+			Message:  message,
+			Severity: r.severity(t),
+		}) {
+			return
+		}
+	}
+
+	if markHappy {
+		r.SetCondition(Condition{
+			Type:     r.happy,
+			Status:   corev1.ConditionFalse,
+			Reason:   reason,
+			Message:  message,
+			Severity: r.severity(r.happy),
 		})
 	}
 }
 
+// MarkReconciling sets the happy condition to Unknown to signal that the
+// resource is being reconciled. Dependent conditions are left untouched.
+func (r conditionsImpl) MarkReconciling(reason, messageFormat string, messageA ...interface{}) {
+	r.MarkUnknown(r.happy, reason, messageFormat, messageA...)
+}
+
+// MarkHappyTrue sets the happy condition to True with reason and message,
+// overriding the status its dependents would give it, e.g. after a manual
+// intervention. The reason should say that it is an override. Dependent
+// conditions are left untouched, so the next Mark call on a dependent
+// recomputes the happy condition from them again.
+func (r conditionsImpl) MarkHappyTrue(reason, message string) {
+	r.SetCondition(Condition{
+		Type:     r.happy,
+		Status:   corev1.ConditionTrue,
+		Reason:   reason,
+		Message:  message,
+		Severity: r.severity(r.happy),
+	})
+}
+
+// MarkDependencyNotReady sets the happy condition to Unknown to say which
+// dependency the resource is waiting on: the first dependent, in the order
+// the dependents were declared, that is Unknown or not set yet. The reason
+// is that dependent's reason, or "<Type>NotReady" if it has none, and the
+// message names the dependent and carries on its message. False trumps
+// Unknown, so if any dependent is False the happy condition is set to False
+// with the reason and message of the first one instead. If all dependents
+// are True, nothing is changed.
+func (r conditionsImpl) MarkDependencyNotReady() {
+	if c, failed := r.HasFailedDependent(); failed {
+		r.SetCondition(Condition{
+			Type:     r.happy,
+			Status:   corev1.ConditionFalse,
+			Reason:   c.Reason,
+			Message:  c.Message,
+			Severity: r.severity(r.happy),
+		})
+		return
+	}
+	for _, t := range r.dependents {
+		c := r.GetCondition(t)
+		if c.IsTrue() {
+			continue
+		}
+		reason, message := string(t)+"NotReady", fmt.Sprintf("waiting for %s", t)
+		if c != nil {
+			if c.Reason != "" {
+				reason = c.Reason
+			}
+			if c.Message != "" {
+				message += ": " + c.Message
+			}
+		}
+		r.SetCondition(Condition{
+			Type:     r.happy,
+			Status:   corev1.ConditionUnknown,
+			Reason:   reason,
+			Message:  message,
+			Severity: r.severity(r.happy),
+		})
+		return
+	}
+}
+
+// MarkFailed sets the happy condition to False to signal that the resource
+// failed terminally. Dependent conditions are left untouched.
+func (r conditionsImpl) MarkFailed(reason, messageFormat string, messageA ...interface{}) {
+	r.MarkFalse(r.happy, reason, messageFormat, messageA...)
+}
+
 // InitializeConditions updates all Conditions in the ConditionSet to Unknown
 // if not set.
 func (r conditionsImpl) InitializeConditions() {
@@ -435,6 +898,22 @@ func (r conditionsImpl) InitializeConditions() {
 	}
 }
 
+// ResetConditions sets the happy condition and every dependent to Unknown
+// with reason and message, e.g. when a change of the spec invalidates what
+// the status said. Unlike InitializeConditions it overwrites conditions that
+// are already set. Conditions outside of the ConditionSet are left untouched.
+func (r conditionsImpl) ResetConditions(reason, message string) {
+	for _, t := range append([]ConditionType{r.happy}, r.dependents...) {
+		r.SetCondition(Condition{
+			Type:     t,
+			Status:   corev1.ConditionUnknown,
+			Reason:   reason,
+			Message:  message,
+			Severity: r.severity(t),
+		})
+	}
+}
+
 // initializeTerminalCondition initializes a Condition to the given status if unset.
 func (r conditionsImpl) initializeTerminalCondition(t ConditionType, status corev1.ConditionStatus) *Condition {
 	if c := r.GetCondition(t); c != nil {
@@ -448,3 +927,35 @@ func (r conditionsImpl) initializeTerminalCondition(t ConditionType, status core
 	r.SetCondition(c)
 	return &c
 }
+
+// TransitionCount returns how often the status of the condition of type t
+// changed since it was first set, as counted by SetCondition in a status
+// that implements TransitionCountAccessor. For other statuses it is 0.
+func (r conditionsImpl) TransitionCount(t ConditionType) int {
+	if tca, ok := r.accessor.(TransitionCountAccessor); ok {
+		return tca.GetTransitionCount(t)
+	}
+	return 0
+}
+
+// MarkObservedGeneration records generation as the observed generation,
+// if the status implements ObservedGenerationAccessor.
+func (r conditionsImpl) MarkObservedGeneration(generation int64) {
+	if oga, ok := r.accessor.(ObservedGenerationAccessor); ok {
+		oga.SetObservedGeneration(generation)
+	}
+}
+
+// IsCurrent returns true if the status implements ObservedGenerationAccessor
+// and has observed generation, usually the object's metadata.generation.
+// A status that does not track its observed generation is never current.
+func (r conditionsImpl) IsCurrent(generation int64) bool {
+	oga, ok := r.accessor.(ObservedGenerationAccessor)
+	return ok && oga.GetObservedGeneration() == generation
+}
+
+// IsCurrentAndHappy returns true if the status has observed generation and
+// the happy condition is true, i.e. the latest spec is known to be ready.
+func (r conditionsImpl) IsCurrentAndHappy(generation int64) bool {
+	return r.IsCurrent(generation) && r.IsHappy()
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package apis
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // TestStatus is to validate ConditionAccessor interface works
@@ -138,6 +140,105 @@ func TestSetCondition(t *testing.T) {
 	}
 }
 
+func TestUpdateCondition(t *testing.T) {
+	condSet := NewLivingConditionSet("Foo")
+	status := &TestStatus{}
+	manager := condSet.Manage(status)
+	manager.InitializeConditions()
+
+	// Mutating the result of GetCondition is not stored back.
+	manager.GetCondition("Foo").Message = "lost"
+	if got := manager.GetCondition("Foo").Message; got != "" {
+		t.Errorf("GetCondition(Foo).Message = %q, wanted empty", got)
+	}
+
+	manager.UpdateCondition("Foo", func(c *Condition) {
+		c.Message = "kept"
+		c.Type = "Bar"
+	})
+	want := &Condition{
+		Type:    "Foo",
+		Status:  corev1.ConditionUnknown,
+		Message: "kept",
+	}
+	if diff := cmp.Diff(want, manager.GetCondition("Foo"), ignoreFields); diff != "" {
+		t.Errorf("UpdateCondition(Foo) (-want, +got) = %v", diff)
+	}
+	if c := manager.GetCondition("Bar"); c != nil {
+		t.Errorf("GetCondition(Bar) = %v, wanted nil", c)
+	}
+
+	// Conditions that are not set yet start out empty.
+	manager.UpdateCondition("Baz", func(c *Condition) {
+		c.Status = corev1.ConditionTrue
+		c.Reason = "Added"
+	})
+	want = &Condition{
+		Type:   "Baz",
+		Status: corev1.ConditionTrue,
+		Reason: "Added",
+	}
+	if diff := cmp.Diff(want, manager.GetCondition("Baz"), ignoreFields); diff != "" {
+		t.Errorf("UpdateCondition(Baz) (-want, +got) = %v", diff)
+	}
+	if got, want := manager.GetCondition("Baz").Severity, ConditionSeverityInfo; got != want {
+		t.Errorf("UpdateCondition(Baz).Severity = %v, wanted %v", got, want)
+	}
+
+	// A nil accessor is a no-op.
+	condSet.Manage(nil).UpdateCondition("Foo", func(*Condition) {
+		t.Error("mutate called with nil accessor")
+	})
+}
+
+func TestConditionOrdering(t *testing.T) {
+	cases := []struct {
+		name    string
+		set     ConditionSet
+		want    []ConditionType
+		wantBaz []ConditionType
+	}{{
+		name:    "alphabetical by default",
+		set:     NewLivingConditionSet("Foo", "Bar"),
+		want:    []ConditionType{"Bar", "Foo", ConditionReady},
+		wantBaz: []ConditionType{"Bar", "Baz", "Foo", ConditionReady},
+	}, {
+		name:    "happy first",
+		set:     NewLivingConditionSet("Foo", "Bar").WithHappyFirst(),
+		want:    []ConditionType{ConditionReady, "Bar", "Foo"},
+		wantBaz: []ConditionType{ConditionReady, "Bar", "Baz", "Foo"},
+	}, {
+		name:    "happy first, happy already sorts first",
+		set:     NewConditionSet("Available", "Foo", "Bar").WithHappyFirst(),
+		want:    []ConditionType{"Available", "Bar", "Foo"},
+		wantBaz: []ConditionType{"Available", "Bar", "Baz", "Foo"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := &TestStatus{}
+			manager := tc.set.Manage(status)
+			manager.InitializeConditions()
+			if got := getTypes(status.c); !cmp.Equal(got, tc.want) {
+				t.Errorf("InitializeConditions() = %v, wanted %v", got, tc.want)
+			}
+
+			// Non-terminal conditions are ordered the same way.
+			manager.MarkTrue("Baz")
+			if got := getTypes(status.c); !cmp.Equal(got, tc.wantBaz) {
+				t.Errorf("MarkTrue(Baz) = %v, wanted %v", got, tc.wantBaz)
+			}
+
+			if err := manager.ClearCondition("Baz"); err != nil {
+				t.Fatal("ClearCondition() =", err)
+			}
+			if got := getTypes(status.c); !cmp.Equal(got, tc.want) {
+				t.Errorf("ClearCondition(Baz) = %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestIsHappy(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -269,6 +370,88 @@ func TestIsHappy(t *testing.T) {
 	}
 }
 
+func TestHasFailedDependent(t *testing.T) {
+	cases := []struct {
+		name       string
+		conditions Conditions
+		want       *Condition
+	}{{
+		name: "all true",
+		conditions: Conditions{{
+			Type:   "Foo",
+			Status: corev1.ConditionTrue,
+		}, {
+			Type:   "Bar",
+			Status: corev1.ConditionTrue,
+		}},
+	}, {
+		name: "one unknown",
+		conditions: Conditions{{
+			Type:   "Foo",
+			Status: corev1.ConditionTrue,
+		}, {
+			Type:   "Bar",
+			Status: corev1.ConditionUnknown,
+		}},
+	}, {
+		name: "one missing",
+		conditions: Conditions{{
+			Type:   "Foo",
+			Status: corev1.ConditionTrue,
+		}},
+	}, {
+		name: "one false",
+		conditions: Conditions{{
+			Type:   "Foo",
+			Status: corev1.ConditionUnknown,
+		}, {
+			Type:   "Bar",
+			Status: corev1.ConditionFalse,
+			Reason: "Broken",
+		}},
+		want: &Condition{
+			Type:   "Bar",
+			Status: corev1.ConditionFalse,
+			Reason: "Broken",
+		},
+	}, {
+		name: "two false, first declared wins",
+		conditions: Conditions{{
+			Type:   "Bar",
+			Status: corev1.ConditionFalse,
+			Reason: "BarBroken",
+		}, {
+			Type:   "Foo",
+			Status: corev1.ConditionFalse,
+			Reason: "FooBroken",
+		}},
+		want: &Condition{
+			Type:   "Foo",
+			Status: corev1.ConditionFalse,
+			Reason: "FooBroken",
+		},
+	}, {
+		name: "happy false is not a dependent",
+		conditions: Conditions{{
+			Type:   ConditionReady,
+			Status: corev1.ConditionFalse,
+		}},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := &TestStatus{c: tc.conditions}
+			got, ok := NewLivingConditionSet("Foo", "Bar").Manage(status).HasFailedDependent()
+			if wantOK := tc.want != nil; ok != wantOK {
+				t.Errorf("HasFailedDependent() = %v, wanted %v", ok, wantOK)
+			}
+			if diff := cmp.Diff(tc.want, got, ignoreFields); diff != "" {
+				t.Errorf("HasFailedDependent() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func TestUpdateLastTransitionTime(t *testing.T) {
 	condSet := NewLivingConditionSet()
 
@@ -922,6 +1105,86 @@ func TestMarkFalse(t *testing.T) {
 	doTestMarkFalseAccessor(t, cases)
 }
 
+func TestMarkFalseMany(t *testing.T) {
+	cases := []struct {
+		name       string
+		dependents []ConditionType
+		conditions Conditions
+		mark       []ConditionType
+		wantFalse  []ConditionType
+		wantHappy  corev1.ConditionStatus
+	}{{
+		name:       "all dependents",
+		dependents: []ConditionType{"Foo", "Bar"},
+		conditions: Conditions{{
+			Type:   ConditionReady,
+			Status: corev1.ConditionTrue,
+		}, {
+			Type:   "Foo",
+			Status: corev1.ConditionTrue,
+		}, {
+			Type:   "Bar",
+			Status: corev1.ConditionTrue,
+		}},
+		mark:      []ConditionType{"Foo", "Bar"},
+		wantFalse: []ConditionType{"Bar", "Foo", ConditionReady},
+		wantHappy: corev1.ConditionFalse,
+	}, {
+		name:       "some dependents",
+		dependents: []ConditionType{"Foo", "Bar", "Baz"},
+		conditions: Conditions{{
+			Type:   ConditionReady,
+			Status: corev1.ConditionUnknown,
+		}},
+		mark:      []ConditionType{"Foo", "Baz"},
+		wantFalse: []ConditionType{"Baz", "Foo", ConditionReady},
+		wantHappy: corev1.ConditionFalse,
+	}, {
+		name:       "non-terminal only",
+		dependents: []ConditionType{"Foo"},
+		conditions: Conditions{{
+			Type:   ConditionReady,
+			Status: corev1.ConditionTrue,
+		}, {
+			Type:   "Foo",
+			Status: corev1.ConditionTrue,
+		}},
+		mark:      []ConditionType{"Bar", "Baz"},
+		wantFalse: []ConditionType{"Bar", "Baz"},
+		wantHappy: corev1.ConditionTrue,
+	}, {
+		name:      "happy only",
+		mark:      []ConditionType{ConditionReady},
+		wantFalse: []ConditionType{ConditionReady},
+		wantHappy: corev1.ConditionFalse,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			condSet := NewLivingConditionSet(tc.dependents...)
+			status := &TestStatus{c: tc.conditions}
+			manager := condSet.Manage(status)
+
+			manager.MarkFalseMany("UnitTest", "calm down, just testing", tc.mark...)
+
+			if got, want := manager.GetTopLevelCondition().Status, tc.wantHappy; got != want {
+				t.Errorf("happy condition status = %v, wanted %v", got, want)
+			}
+			for _, ct := range tc.wantFalse {
+				want := &Condition{
+					Type:    ct,
+					Status:  corev1.ConditionFalse,
+					Reason:  "UnitTest",
+					Message: "calm down, just testing",
+				}
+				if diff := cmp.Diff(want, manager.GetCondition(ct), ignoreFields); diff != "" {
+					t.Errorf("GetCondition(%q) (-want, +got) = %v", ct, diff)
+				}
+			}
+		})
+	}
+}
+
 type ConditionMarkUnknownTest struct {
 	name       string
 	conditions Conditions
@@ -1047,6 +1310,78 @@ func TestMarkUnknown(t *testing.T) {
 	doTestMarkUnknownAccessor(t, cases)
 }
 
+func TestMarkReconcilingAndFailed(t *testing.T) {
+	condSet := NewLivingConditionSet("Foo")
+	status := &TestStatus{}
+	manager := condSet.Manage(status)
+	manager.InitializeConditions()
+	manager.MarkTrue("Foo")
+	if !manager.IsHappy() {
+		t.Fatal("IsHappy() = false after all dependents are true")
+	}
+
+	manager.MarkReconciling("Reconciling", "working on %s", "it")
+	if manager.IsHappy() {
+		t.Error("IsHappy() = true while reconciling")
+	}
+	want := &Condition{
+		Type:    ConditionReady,
+		Status:  corev1.ConditionUnknown,
+		Reason:  "Reconciling",
+		Message: "working on it",
+	}
+	if diff := cmp.Diff(want, manager.GetTopLevelCondition(), ignoreFields); diff != "" {
+		t.Errorf("MarkReconciling() (-want, +got) = %v", diff)
+	}
+	if !manager.GetCondition("Foo").IsTrue() {
+		t.Error("MarkReconciling() changed a dependent condition")
+	}
+
+	manager.MarkFailed("Stalled", "gave up after %d attempts", 3)
+	if manager.IsHappy() {
+		t.Error("IsHappy() = true after failing")
+	}
+	want = &Condition{
+		Type:    ConditionReady,
+		Status:  corev1.ConditionFalse,
+		Reason:  "Stalled",
+		Message: "gave up after 3 attempts",
+	}
+	if diff := cmp.Diff(want, manager.GetTopLevelCondition(), ignoreFields); diff != "" {
+		t.Errorf("MarkFailed() (-want, +got) = %v", diff)
+	}
+	if !manager.GetCondition("Foo").IsTrue() {
+		t.Error("MarkFailed() changed a dependent condition")
+	}
+}
+
+func TestMarkUnknownExpandsMessageArgs(t *testing.T) {
+	condSet := NewLivingConditionSet("Foo", "Bar")
+	status := &TestStatus{c: Conditions{{
+		Type:   ConditionReady,
+		Status: corev1.ConditionUnknown,
+	}, {
+		Type:   "Foo",
+		Status: corev1.ConditionFalse,
+	}}}
+	manager := condSet.Manage(status)
+
+	// A False dependent turns the happy condition False with the message
+	// passed to MarkUnknown, which has to be rendered the same way.
+	manager.MarkUnknown("Bar", "UnitTest", "%s and %d more", "one", 2)
+
+	if got, want := manager.GetCondition("Bar").Message, "one and 2 more"; got != want {
+		t.Errorf("GetCondition(Bar).Message = %q, wanted %q", got, want)
+	}
+	happy := manager.GetTopLevelCondition()
+	if got, want := happy.Status, corev1.ConditionFalse; got != want {
+		t.Errorf("happy condition status = %v, wanted %v", got, want)
+	}
+	if got, want := happy.Message, "one and 2 more"; got != want {
+		t.Errorf("happy condition message = %q, wanted %q", got, want)
+	}
+}
+
 func TestInitializeConditions(t *testing.T) {
 	condSet := NewLivingConditionSet()
 
@@ -1173,3 +1508,530 @@ func TestClearConditionWithNilManager(t *testing.T) {
 	}
 
 }
+
+func TestStrictReasons(t *testing.T) {
+	var rejected []error
+	condSet := NewLivingConditionSet("Foo").
+		WithReasons("Foo", "NotReady").
+		WithStrictReasons(func(err error) {
+			rejected = append(rejected, err)
+		})
+	status := &TestStatus{}
+	manager := condSet.Manage(status)
+	manager.InitializeConditions()
+
+	// Known reasons, also when mirrored on the happy condition, are accepted.
+	manager.MarkFalse("Foo", "NotReady", "not yet")
+	if got := manager.GetTopLevelCondition(); got.Reason != "NotReady" {
+		t.Errorf("GetTopLevelCondition().Reason = %q, wanted NotReady", got.Reason)
+	}
+	manager.MarkTrue("Foo")
+	if len(rejected) != 0 {
+		t.Fatalf("Known reasons were rejected: %v", rejected)
+	}
+
+	manager.MarkFalse("Foo", "Unready", "drifted")
+	if !manager.IsHappy() {
+		t.Error("MarkFalse() with an unknown reason changed the conditions")
+	}
+	if len(rejected) == 0 {
+		t.Error("MarkFalse() with an unknown reason was not reported")
+	}
+}
+
+func TestStrictReasonsWithoutHandler(t *testing.T) {
+	condSet := NewLivingConditionSet("Foo").
+		WithReasons("Foo", "NotReady").
+		WithStrictReasons(nil)
+	manager := condSet.Manage(&TestStatus{})
+	manager.InitializeConditions()
+	manager.MarkTrue("Foo")
+
+	// Without a handler unknown reasons are silently rejected.
+	manager.MarkFalse("Foo", "Unready", "drifted")
+	if !manager.IsHappy() {
+		t.Error("MarkFalse() with an unknown reason changed the conditions")
+	}
+}
+
+func TestLenientReasons(t *testing.T) {
+	condSet := NewLivingConditionSet("Foo").WithReasons("Foo", "NotReady")
+	manager := condSet.Manage(&TestStatus{})
+	manager.InitializeConditions()
+
+	// Without WithStrictReasons unknown reasons are stored as usual.
+	manager.MarkFalse("Foo", "Unready", "drifted")
+	if got := manager.GetCondition("Foo"); got.Reason != "Unready" {
+		t.Errorf("GetCondition(Foo).Reason = %q, wanted Unready", got.Reason)
+	}
+}
+
+// generationStatus is a TestStatus that also tracks its observed generation.
+type generationStatus struct {
+	TestStatus
+	observedGeneration int64
+}
+
+func (s *generationStatus) GetObservedGeneration() int64 {
+	return s.observedGeneration
+}
+
+func (s *generationStatus) SetObservedGeneration(generation int64) {
+	s.observedGeneration = generation
+}
+
+func TestObservedGeneration(t *testing.T) {
+	condSet := NewLivingConditionSet("Foo")
+	status := &generationStatus{}
+	manager := condSet.Manage(status)
+	manager.InitializeConditions()
+
+	if manager.IsCurrent(1) {
+		t.Error("IsCurrent(1) = true before observing any generation")
+	}
+
+	manager.MarkObservedGeneration(1)
+	manager.MarkTrue("Foo")
+	if got, want := status.observedGeneration, int64(1); got != want {
+		t.Errorf("observed generation = %d, wanted %d", got, want)
+	}
+	if !manager.IsCurrent(1) {
+		t.Error("IsCurrent(1) = false after observing generation 1")
+	}
+	if !manager.IsCurrentAndHappy(1) {
+		t.Error("IsCurrentAndHappy(1) = false for a happy, current status")
+	}
+
+	// The spec moved on, so the status is stale even though it is happy.
+	if manager.IsCurrent(2) {
+		t.Error("IsCurrent(2) = true for a stale status")
+	}
+	if manager.IsCurrentAndHappy(2) {
+		t.Error("IsCurrentAndHappy(2) = true for a stale status")
+	}
+
+	manager.MarkObservedGeneration(2)
+	manager.MarkFalse("Foo", "Broken", "")
+	if !manager.IsCurrent(2) {
+		t.Error("IsCurrent(2) = false after observing generation 2")
+	}
+	if manager.IsCurrentAndHappy(2) {
+		t.Error("IsCurrentAndHappy(2) = true for an unhappy status")
+	}
+}
+
+func TestObservedGenerationUntracked(t *testing.T) {
+	manager := NewLivingConditionSet().Manage(&TestStatus{})
+	manager.MarkTrue(ConditionReady)
+
+	// Statuses that don't track their generation are never current.
+	manager.MarkObservedGeneration(0)
+	if manager.IsCurrent(0) {
+		t.Error("IsCurrent(0) = true for a status without observed generation")
+	}
+	if manager.IsCurrentAndHappy(0) {
+		t.Error("IsCurrentAndHappy(0) = true for a status without observed generation")
+	}
+}
+
+type fakeRecorder struct {
+	events []string
+}
+
+func (r *fakeRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.events = append(r.events, eventtype+" "+reason+" "+fmt.Sprintf(messageFmt, args...))
+}
+
+func TestManageWithRecorder(t *testing.T) {
+	recorder := &fakeRecorder{}
+	object := &metav1.PartialObjectMetadata{}
+	manager := NewLivingConditionSet("Foo").ManageWithRecorder(&TestStatus{}, recorder, object)
+
+	steps := []struct {
+		name string
+		do   func()
+		want []string
+	}{{
+		name: "initialize",
+		do:   manager.InitializeConditions,
+	}, {
+		name: "mark false",
+		do:   func() { manager.MarkFalse("Foo", "Broken", "it %s", "broke") },
+		want: []string{"Warning Broken it broke", "Warning Broken it broke"},
+	}, {
+		name: "mark false again",
+		do:   func() { manager.MarkFalse("Foo", "Broken", "it %s", "broke") },
+	}, {
+		name: "new reason, same status",
+		do:   func() { manager.MarkFalse("Foo", "StillBroken", "") },
+	}, {
+		name: "mark true",
+		do:   func() { manager.MarkTrue("Foo") },
+		want: []string{"Normal FooTrue Foo is True", "Normal ReadyTrue Ready is True"},
+	}, {
+		name: "mark true again",
+		do:   func() { manager.MarkTrue("Foo") },
+	}, {
+		name: "mark unknown",
+		do:   func() { manager.MarkUnknown("Foo", "Checking", "") },
+	}, {
+		name: "set condition true with reason",
+		do: func() {
+			manager.SetCondition(Condition{Type: "Foo", Status: corev1.ConditionTrue, Reason: "Fixed", Message: "all good"})
+		},
+		want: []string{"Normal Fixed all good"},
+	}}
+
+	for _, step := range steps {
+		recorder.events = nil
+		step.do()
+		if !cmp.Equal(recorder.events, step.want) {
+			t.Errorf("%s: events = %q, want: %q", step.name, recorder.events, step.want)
+		}
+	}
+
+	// Without a recorder nothing is emitted.
+	NewLivingConditionSet("Foo").Manage(&TestStatus{}).MarkFalse("Foo", "Broken", "")
+}
+
+func TestHappyReasonMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		mark        func(ConditionManager)
+		wantReason  string
+		wantMessage string
+	}{{
+		name: "absent",
+		mark: func(ConditionManager) {},
+	}, {
+		name:        "unknown",
+		mark:        func(m ConditionManager) { m.MarkUnknown("Foo", "Waiting", "waiting for %s", "foo") },
+		wantReason:  "Waiting",
+		wantMessage: "waiting for foo",
+	}, {
+		name:        "false",
+		mark:        func(m ConditionManager) { m.MarkFalse("Foo", "Broken", "foo is broken") },
+		wantReason:  "Broken",
+		wantMessage: "foo is broken",
+	}, {
+		name: "true",
+		mark: func(m ConditionManager) {
+			m.MarkFalse("Foo", "Broken", "foo is broken")
+			m.MarkTrueWithReason("Foo", "Fixed", "foo is fixed")
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager := NewLivingConditionSet("Foo").Manage(&TestStatus{})
+			test.mark(manager)
+			reason, message := manager.HappyReasonMessage()
+			if reason != test.wantReason || message != test.wantMessage {
+				t.Errorf("HappyReasonMessage() = (%q, %q), want: (%q, %q)", reason, message, test.wantReason, test.wantMessage)
+			}
+		})
+	}
+}
+
+func TestMarkHappyTrue(t *testing.T) {
+	status := &TestStatus{}
+	manager := NewLivingConditionSet("Foo", "Bar").Manage(status)
+	manager.InitializeConditions()
+	manager.MarkTrue("Bar")
+
+	manager.MarkHappyTrue("ManualOverride", "forced ready by operator")
+	happy := manager.GetTopLevelCondition()
+	if !happy.IsTrue() {
+		t.Fatalf("happy condition = %v, want True", happy)
+	}
+	if happy.Reason != "ManualOverride" || happy.Message != "forced ready by operator" {
+		t.Errorf("happy reason, message = %q, %q, want the override", happy.Reason, happy.Message)
+	}
+	if !manager.IsHappy() {
+		t.Error("IsHappy() = false after MarkHappyTrue")
+	}
+	// The dependents are untouched.
+	if foo := manager.GetCondition("Foo"); !foo.IsUnknown() {
+		t.Errorf("Foo = %v, want Unknown", foo)
+	}
+
+	// The next change to a dependent recomputes the happy condition.
+	manager.MarkUnknown("Bar", "Checking", "")
+	if manager.IsHappy() {
+		t.Error("IsHappy() = true after a dependent became Unknown")
+	}
+}
+
+func TestMarkUnknownUnsetAndUnknownDependents(t *testing.T) {
+	// A dependent that is not set yet and one that is Unknown are treated
+	// the same: neither counts as good, neither trumps the new Unknown.
+	tests := []struct {
+		name       string
+		mark       func(ConditionManager)
+		wantStatus corev1.ConditionStatus
+		wantReason string
+	}{{
+		name:       "other dependent not set",
+		mark:       func(ConditionManager) {},
+		wantStatus: corev1.ConditionUnknown,
+		wantReason: "BarPending",
+	}, {
+		name: "other dependent unknown",
+		mark: func(m ConditionManager) {
+			m.MarkUnknown("Foo", "FooPending", "")
+		},
+		wantStatus: corev1.ConditionUnknown,
+		wantReason: "BarPending",
+	}, {
+		name: "other dependent true",
+		mark: func(m ConditionManager) {
+			m.MarkTrue("Foo")
+		},
+		wantStatus: corev1.ConditionUnknown,
+		wantReason: "BarPending",
+	}, {
+		name: "other dependent false",
+		mark: func(m ConditionManager) {
+			m.MarkFalse("Foo", "FooBroken", "")
+		},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: "FooBroken",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager := NewLivingConditionSet("Foo", "Bar").Manage(&TestStatus{})
+			test.mark(manager)
+			manager.MarkUnknown("Bar", "BarPending", "")
+			happy := manager.GetTopLevelCondition()
+			if happy.Status != test.wantStatus || happy.Reason != test.wantReason {
+				t.Errorf("happy = %s %q, want: %s %q", happy.Status, happy.Reason, test.wantStatus, test.wantReason)
+			}
+			if got := manager.GetCondition("Bar"); !got.IsUnknown() {
+				t.Errorf("GetCondition(Bar) = %v, want Unknown", got)
+			}
+		})
+	}
+}
+
+func TestMarkDependencyNotReady(t *testing.T) {
+	tests := []struct {
+		name        string
+		mark        func(ConditionManager)
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+		wantMessage string
+	}{{
+		name: "first dependent not ready",
+		mark: func(m ConditionManager) {
+			m.MarkUnknown("Foo", "WaitingForDB", "database %q is starting", "db")
+			m.MarkFalse("Bar", "Broken", "bar is broken")
+		},
+		wantStatus:  corev1.ConditionFalse,
+		wantReason:  "Broken",
+		wantMessage: "bar is broken",
+	}, {
+		name: "first dependent unknown",
+		mark: func(m ConditionManager) {
+			m.MarkUnknown("Foo", "WaitingForDB", "database %q is starting", "db")
+			m.MarkTrue("Bar")
+		},
+		wantStatus:  corev1.ConditionUnknown,
+		wantReason:  "WaitingForDB",
+		wantMessage: `waiting for Foo: database "db" is starting`,
+	}, {
+		name: "failed dependent restores overwritten happy condition",
+		mark: func(m ConditionManager) {
+			m.MarkFalse("Foo", "Broken", "foo is broken")
+			m.MarkReconciling("Reconciling", "")
+		},
+		wantStatus:  corev1.ConditionFalse,
+		wantReason:  "Broken",
+		wantMessage: "foo is broken",
+	}, {
+		name: "second dependent not ready",
+		mark: func(m ConditionManager) {
+			m.MarkTrue("Foo")
+			m.MarkUnknown("Bar", "WaitingForBar", "")
+		},
+		wantStatus:  corev1.ConditionUnknown,
+		wantReason:  "WaitingForBar",
+		wantMessage: "waiting for Bar",
+	}, {
+		name: "dependent not set",
+		mark: func(m ConditionManager) {
+			m.MarkTrue("Bar")
+		},
+		wantStatus:  corev1.ConditionUnknown,
+		wantReason:  "FooNotReady",
+		wantMessage: "waiting for Foo",
+	}, {
+		name: "all dependents ready",
+		mark: func(m ConditionManager) {
+			m.MarkTrue("Foo")
+			m.MarkTrue("Bar")
+		},
+		wantStatus: corev1.ConditionTrue,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager := NewLivingConditionSet("Foo", "Bar").Manage(&TestStatus{})
+			test.mark(manager)
+			manager.MarkDependencyNotReady()
+			happy := manager.GetTopLevelCondition()
+			if happy.Status != test.wantStatus || happy.Reason != test.wantReason || happy.Message != test.wantMessage {
+				t.Errorf("happy = %s %q %q, want: %s %q %q", happy.Status, happy.Reason, happy.Message,
+					test.wantStatus, test.wantReason, test.wantMessage)
+			}
+		})
+	}
+}
+
+func TestBlockingDependents(t *testing.T) {
+	manager := NewLivingConditionSet("Foo", "Bar", "Baz", "Qux").Manage(&TestStatus{})
+	if got := manager.BlockingDependents(); len(got) != 4 {
+		t.Errorf("BlockingDependents() = %v, want all 4 dependents", got)
+	}
+
+	manager.MarkTrue("Foo")
+	manager.MarkFalse("Qux", "QuxBroken", "qux is broken")
+	manager.MarkUnknown("Bar", "BarPending", "")
+
+	got := manager.BlockingDependents()
+	want := []Condition{{
+		Type:   "Bar",
+		Status: corev1.ConditionUnknown,
+		Reason: "BarPending",
+	}, {
+		Type:   "Baz",
+		Status: corev1.ConditionUnknown,
+	}, {
+		Type:    "Qux",
+		Status:  corev1.ConditionFalse,
+		Reason:  "QuxBroken",
+		Message: "qux is broken",
+	}}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Condition{}, "LastTransitionTime")); diff != "" {
+		t.Error("BlockingDependents (-want, +got) =", diff)
+	}
+
+	for _, ct := range []ConditionType{"Bar", "Baz", "Qux"} {
+		manager.MarkTrue(ct)
+	}
+	if got := manager.BlockingDependents(); len(got) != 0 {
+		t.Errorf("BlockingDependents() = %v, want none", got)
+	}
+}
+
+func TestResetConditions(t *testing.T) {
+	status := &TestStatus{}
+	manager := NewLivingConditionSet("Foo", "Bar").Manage(status)
+	manager.MarkTrue("Foo")
+	manager.MarkFalse("Bar", "Broken", "bar is broken")
+	manager.SetCondition(Condition{Type: "Extra", Status: corev1.ConditionTrue, Severity: ConditionSeverityInfo})
+
+	manager.ResetConditions("SpecChanged", "the spec changed")
+
+	for _, ct := range []ConditionType{ConditionReady, "Foo", "Bar"} {
+		c := manager.GetCondition(ct)
+		if !c.IsUnknown() || c.Reason != "SpecChanged" || c.Message != "the spec changed" {
+			t.Errorf("%s = %s %q %q, want Unknown with the reset reason", ct, c.Status, c.Reason, c.Message)
+		}
+		if c.Severity != ConditionSeverityError {
+			t.Errorf("%s severity = %q, want Error", ct, c.Severity)
+		}
+	}
+	if c := manager.GetCondition("Extra"); !c.IsTrue() {
+		t.Errorf("Extra = %v, want it untouched", c)
+	}
+
+	// Resetting again with the same reason is a no-op.
+	before := status.GetConditions().DeepCopy()
+	manager.ResetConditions("SpecChanged", "the spec changed")
+	if diff := cmp.Diff(before, status.GetConditions()); diff != "" {
+		t.Error("second ResetConditions changed the conditions (-before, +after) =", diff)
+	}
+}
+
+type transitionCountStatus struct {
+	TestStatus
+	counts map[ConditionType]int
+}
+
+func (s *transitionCountStatus) GetTransitionCount(t ConditionType) int {
+	return s.counts[t]
+}
+
+func (s *transitionCountStatus) SetTransitionCount(t ConditionType, count int) {
+	if s.counts == nil {
+		s.counts = make(map[ConditionType]int)
+	}
+	s.counts[t] = count
+}
+
+func TestTransitionCount(t *testing.T) {
+	manager := NewLivingConditionSet("Foo").Manage(&transitionCountStatus{})
+	manager.InitializeConditions()
+	if got := manager.TransitionCount("Foo"); got != 0 {
+		t.Errorf("TransitionCount(Foo) = %d after initializing, want 0", got)
+	}
+
+	steps := []struct {
+		mark      func()
+		wantFoo   int
+		wantReady int
+	}{{
+		mark:      func() { manager.MarkTrue("Foo") },
+		wantFoo:   1,
+		wantReady: 1,
+	}, {
+		// Same status, new reason: no transition.
+		mark:      func() { manager.MarkTrueWithReason("Foo", "StillGood", "") },
+		wantFoo:   1,
+		wantReady: 1,
+	}, {
+		mark:      func() { manager.MarkFalse("Foo", "Broken", "") },
+		wantFoo:   2,
+		wantReady: 2,
+	}, {
+		mark:      func() { manager.MarkTrue("Foo") },
+		wantFoo:   3,
+		wantReady: 3,
+	}, {
+		mark:      func() { manager.MarkUnknown("Foo", "Checking", "") },
+		wantFoo:   4,
+		wantReady: 4,
+	}}
+	for i, step := range steps {
+		step.mark()
+		if got := manager.TransitionCount("Foo"); got != step.wantFoo {
+			t.Errorf("step %d: TransitionCount(Foo) = %d, want: %d", i, got, step.wantFoo)
+		}
+		if got := manager.TransitionCount(ConditionReady); got != step.wantReady {
+			t.Errorf("step %d: TransitionCount(Ready) = %d, want: %d", i, got, step.wantReady)
+		}
+	}
+
+	// Clearing a condition resets its count.
+	manager.SetCondition(Condition{Type: "Bar", Status: corev1.ConditionTrue})
+	manager.SetCondition(Condition{Type: "Bar", Status: corev1.ConditionFalse})
+	if got := manager.TransitionCount("Bar"); got != 1 {
+		t.Errorf("TransitionCount(Bar) = %d, want: 1", got)
+	}
+	if err := manager.ClearCondition("Bar"); err != nil {
+		t.Fatal("ClearCondition(Bar) =", err)
+	}
+	manager.SetCondition(Condition{Type: "Bar", Status: corev1.ConditionTrue})
+	if got := manager.TransitionCount("Bar"); got != 0 {
+		t.Errorf("TransitionCount(Bar) = %d after clearing and setting again, want: 0", got)
+	}
+
+	// Statuses that don't count transitions report 0.
+	untracked := NewLivingConditionSet("Foo").Manage(&TestStatus{})
+	untracked.MarkTrue("Foo")
+	untracked.MarkFalse("Foo", "Broken", "")
+	if got := untracked.TransitionCount("Foo"); got != 0 {
+		t.Errorf("TransitionCount(Foo) = %d for an untracked status, want 0", got)
+	}
+}
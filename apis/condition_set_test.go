@@ -19,6 +19,7 @@ package apis
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -129,3 +130,271 @@ func TestNonTerminalCondition(t *testing.T) {
 		t.Errorf("MarkFalse(Bar) = %v, wanted %v", got, want)
 	}
 }
+
+func TestNewConditionSet(t *testing.T) {
+	const available ConditionType = "Available"
+	cases := []struct {
+		name  string
+		types []ConditionType
+		count int // count includes the happy condition type.
+	}{{
+		name:  "empty",
+		types: []ConditionType(nil),
+		count: 1,
+	}, {
+		name:  "one",
+		types: []ConditionType{"Foo"},
+		count: 2,
+	}, {
+		name:  "duplicate in happy",
+		types: []ConditionType{available},
+		count: 1,
+	}, {
+		name:  "duplicate in dependents",
+		types: []ConditionType{"Foo", "Bar", "Foo"},
+		count: 3,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			set := NewConditionSet(available, tc.types...)
+			if e, a := tc.count, 1+len(set.dependents); e != a {
+				t.Errorf("%q expected: %v got: %v", tc.name, e, a)
+			}
+			if got, want := set.GetTopLevelConditionType(), available; got != want {
+				t.Errorf("GetTopLevelConditionType() = %v, wanted %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCustomHappyConditionRollup(t *testing.T) {
+	const available ConditionType = "Available"
+	set := NewConditionSet(available, "Foo", "Bar")
+	status := &TestStatus{}
+
+	manager := set.Manage(status)
+	manager.InitializeConditions()
+
+	if got, want := len(status.c), 3; got != want {
+		t.Errorf("InitializeConditions() = %v, wanted %v", got, want)
+	}
+	if manager.IsHappy() {
+		t.Error("IsHappy() = true before any dependent is marked true")
+	}
+
+	manager.MarkTrue("Foo")
+	if got, want := manager.GetCondition(available).Status, corev1.ConditionUnknown; got != want {
+		t.Errorf("MarkTrue(Foo) = %v, wanted %v", got, want)
+	}
+
+	manager.MarkTrue("Bar")
+	if got, want := manager.GetCondition(available).Status, corev1.ConditionTrue; got != want {
+		t.Errorf("MarkTrue(Bar) = %v, wanted %v", got, want)
+	}
+	if !manager.IsHappy() {
+		t.Error("IsHappy() = false after all dependents are marked true")
+	}
+	if c := manager.GetCondition(ConditionReady); c != nil {
+		t.Errorf("GetCondition(Ready) = %v, wanted nil", c)
+	}
+
+	manager.MarkFalse("Foo", "Broken", "foo is broken")
+	if got, want := manager.GetCondition(available).Status, corev1.ConditionFalse; got != want {
+		t.Errorf("MarkFalse(Foo) = %v, wanted %v", got, want)
+	}
+}
+
+func TestGetDependentConditionTypes(t *testing.T) {
+	set := NewLivingConditionSet("Foo", "Bar", ConditionReady, "Foo")
+
+	if got, want := set.GetTopLevelConditionType(), ConditionReady; got != want {
+		t.Errorf("GetTopLevelConditionType() = %v, wanted %v", got, want)
+	}
+	want := []ConditionType{"Foo", "Bar"}
+	got := set.GetDependentConditionTypes()
+	if !cmp.Equal(got, want) {
+		t.Errorf("GetDependentConditionTypes() = %v, wanted %v", got, want)
+	}
+
+	// Mutating the result must not leak into the set.
+	got[0] = "Baz"
+	if got := set.GetDependentConditionTypes(); !cmp.Equal(got, want) {
+		t.Errorf("GetDependentConditionTypes() after mutation = %v, wanted %v", got, want)
+	}
+
+	if got := NewBatchConditionSet().GetDependentConditionTypes(); len(got) != 0 {
+		t.Errorf("GetDependentConditionTypes() = %v, wanted empty", got)
+	}
+}
+
+func TestIsKnownReason(t *testing.T) {
+	base := NewLivingConditionSet("Foo", "Bar")
+	set := base.WithReasons("Foo", "NotReady").WithReasons("Foo", "Failed").WithReasons(ConditionReady, "Reconciling")
+
+	tests := []struct {
+		name   string
+		typ    ConditionType
+		reason string
+		want   bool
+	}{{
+		name: "empty reason",
+		typ:  "Foo",
+		want: true,
+	}, {
+		name:   "registered reason",
+		typ:    "Foo",
+		reason: "NotReady",
+		want:   true,
+	}, {
+		name:   "reason registered later",
+		typ:    "Foo",
+		reason: "Failed",
+		want:   true,
+	}, {
+		name:   "unregistered reason",
+		typ:    "Foo",
+		reason: "Unready",
+	}, {
+		name:   "type without registered reasons",
+		typ:    "Bar",
+		reason: "Anything",
+		want:   true,
+	}, {
+		name:   "happy with its own reason",
+		typ:    ConditionReady,
+		reason: "Reconciling",
+		want:   true,
+	}, {
+		name:   "happy with a dependent's reason",
+		typ:    ConditionReady,
+		reason: "NotReady",
+		want:   true,
+	}, {
+		name:   "happy with an unregistered reason",
+		typ:    ConditionReady,
+		reason: "Unready",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := set.IsKnownReason(test.typ, test.reason); got != test.want {
+				t.Errorf("IsKnownReason(%v, %q) = %v, wanted %v", test.typ, test.reason, got, test.want)
+			}
+		})
+	}
+
+	// Registering reasons must not leak into the set it was derived from.
+	if !base.IsKnownReason("Foo", "Unready") {
+		t.Error("WithReasons() modified the original ConditionSet")
+	}
+}
+
+func TestConditionSetValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		set  ConditionSet
+		want string
+	}{{
+		name: "living",
+		set:  NewLivingConditionSet("Foo", "Bar"),
+	}, {
+		name: "batch without dependents",
+		set:  NewBatchConditionSet(),
+	}, {
+		name: "duplicate dependent",
+		set:  NewLivingConditionSet("Foo", "Bar", "Foo"),
+		want: `invalid ConditionSet for "Ready": dependent "Foo" is listed more than once`,
+	}, {
+		name: "happy as dependent",
+		set:  NewConditionSet("Available", "Foo", "Available"),
+		want: `invalid ConditionSet for "Available": dependent "Available" is the happy condition`,
+	}, {
+		name: "several mistakes",
+		set:  NewLivingConditionSet(ConditionReady, "Foo", "", "Foo"),
+		want: `invalid ConditionSet for "Ready": dependent "Ready" is the happy condition, a dependent condition type is empty, dependent "Foo" is listed more than once`,
+	}, {
+		name: "empty happy",
+		set:  NewConditionSet(""),
+		want: `invalid ConditionSet for "": the happy condition type is empty`,
+	}, {
+		name: "derived sets keep the mistakes",
+		set:  NewLivingConditionSet("Foo", "Foo").WithHappyFirst().WithReasons("Foo", "Bar"),
+		want: `invalid ConditionSet for "Ready": dependent "Foo" is listed more than once`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.set.Validate()
+			if test.want == "" {
+				if err != nil {
+					t.Error("Validate() =", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.want {
+				t.Errorf("Validate() = %v, wanted %s", err, test.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name string
+		set  ConditionSet
+		mark func(ConditionManager)
+		want string
+	}{{
+		name: "not initialized",
+		set:  NewLivingConditionSet("Foo"),
+		mark: func(ConditionManager) {},
+		want: "Reconciling",
+	}, {
+		name: "reconciling",
+		set:  NewLivingConditionSet("Foo"),
+		mark: func(m ConditionManager) {
+			m.InitializeConditions()
+			m.MarkReconciling("Working", "")
+		},
+		want: "Reconciling",
+	}, {
+		name: "ready",
+		set:  NewLivingConditionSet("Foo"),
+		mark: func(m ConditionManager) { m.MarkTrue("Foo") },
+		want: "Ready",
+	}, {
+		name: "succeeded",
+		set:  NewBatchConditionSet(),
+		mark: func(m ConditionManager) { m.MarkTrue(ConditionSucceeded) },
+		want: "Succeeded",
+	}, {
+		name: "failed dependent",
+		set:  NewLivingConditionSet("Foo", "Bar"),
+		mark: func(m ConditionManager) {
+			m.MarkFalse("Bar", "BarBroken", "")
+			m.MarkFailed("Overall", "")
+		},
+		want: "BarBroken",
+	}, {
+		name: "failed",
+		set:  NewLivingConditionSet("Foo"),
+		mark: func(m ConditionManager) { m.MarkFailed("Timeout", "took too long") },
+		want: "Timeout",
+	}, {
+		name: "failed without reason",
+		set:  NewLivingConditionSet("Foo"),
+		mark: func(m ConditionManager) { m.MarkFalse("Foo", "", "") },
+		want: "Failed",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := &TestStatus{}
+			test.mark(test.set.Manage(status))
+			if got := test.set.Summarize(status); got != test.want {
+				t.Errorf("Summarize() = %q, want: %q", got, test.want)
+			}
+		})
+	}
+}
@@ -108,6 +108,17 @@ func (c *Condition) IsUnknown() bool {
 	return c.Status == corev1.ConditionUnknown
 }
 
+// EqualIgnoringTime is true if c and o only differ in their
+// LastTransitionTime, or are both nil.
+func (c *Condition) EqualIgnoringTime(o *Condition) bool {
+	if c == nil || o == nil {
+		return c == o
+	}
+	a, b := *c, *o
+	a.LastTransitionTime, b.LastTransitionTime = VolatileTime{}, VolatileTime{}
+	return a == b
+}
+
 // GetReason returns a nil save string of Reason
 func (c *Condition) GetReason() string {
 	if c == nil {
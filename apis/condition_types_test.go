@@ -212,6 +212,72 @@ func TestIsUnknown(t *testing.T) {
 	}
 }
 
+func TestEqualIgnoringTime(t *testing.T) {
+	base := &Condition{
+		Type:               ConditionReady,
+		Status:             corev1.ConditionFalse,
+		Severity:           ConditionSeverityError,
+		LastTransitionTime: VolatileTime{metav1.NewTime(time.Unix(1024, 0))},
+		Reason:             "Broken",
+		Message:            "it is broken",
+	}
+	with := func(f func(*Condition)) *Condition {
+		c := *base
+		f(&c)
+		return &c
+	}
+
+	cases := []struct {
+		name  string
+		a, b  *Condition
+		equal bool
+	}{{
+		name:  "both nil",
+		equal: true,
+	}, {
+		name: "one nil",
+		a:    base,
+	}, {
+		name:  "same",
+		a:     base,
+		b:     with(func(*Condition) {}),
+		equal: true,
+	}, {
+		name: "only transition time differs",
+		a:    base,
+		b: with(func(c *Condition) {
+			c.LastTransitionTime = VolatileTime{metav1.NewTime(time.Unix(2048, 0))}
+		}),
+		equal: true,
+	}, {
+		name: "status differs",
+		a:    base,
+		b:    with(func(c *Condition) { c.Status = corev1.ConditionTrue }),
+	}, {
+		name: "severity differs",
+		a:    base,
+		b:    with(func(c *Condition) { c.Severity = ConditionSeverityInfo }),
+	}, {
+		name: "reason differs",
+		a:    base,
+		b:    with(func(c *Condition) { c.Reason = "Other" }),
+	}, {
+		name: "message differs",
+		a:    base,
+		b:    with(func(c *Condition) { c.Message = "other" }),
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.a.EqualIgnoringTime(tc.b); got != tc.equal {
+				t.Errorf("EqualIgnoringTime() = %v, wanted %v", got, tc.equal)
+			}
+			if got := tc.b.EqualIgnoringTime(tc.a); got != tc.equal {
+				t.Errorf("reversed EqualIgnoringTime() = %v, wanted %v", got, tc.equal)
+			}
+		})
+	}
+}
+
 func TestJSON(t *testing.T) {
 	cases := []struct {
 		name      string
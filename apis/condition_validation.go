@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"sync"
+
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+var (
+	wellKnownMu sync.RWMutex
+	// wellKnownConditionTypes holds the condition types defined by this
+	// package and those added with RegisterConditionType.
+	wellKnownConditionTypes = map[ConditionType]struct{}{
+		ConditionReady:     {},
+		ConditionSucceeded: {},
+	}
+)
+
+// RegisterConditionType adds types to the condition types that
+// IsWellKnownConditionType accepts, e.g. from the init function of a package
+// that defines its own condition types.
+func RegisterConditionType(types ...ConditionType) {
+	wellKnownMu.Lock()
+	defer wellKnownMu.Unlock()
+
+	for _, t := range types {
+		wellKnownConditionTypes[t] = struct{}{}
+	}
+}
+
+// IsWellKnownConditionType returns true if t is one of the condition types
+// defined by this package, i.e. ConditionReady or ConditionSucceeded, or was
+// added with RegisterConditionType.
+func IsWellKnownConditionType(t ConditionType) bool {
+	wellKnownMu.RLock()
+	defer wellKnownMu.RUnlock()
+
+	_, ok := wellKnownConditionTypes[t]
+	return ok
+}
+
+// ValidateConditionType validates that t is a qualified name, e.g. "Ready"
+// or "example.com/ContainersHealthy", the same rule that applies to label
+// keys. The path of the returned error is the current field.
+func ValidateConditionType(t ConditionType) *FieldError {
+	if t == "" {
+		return ErrMissingField(CurrentField)
+	}
+	if msgs := utilvalidation.IsQualifiedName(string(t)); len(msgs) > 0 {
+		return errInvalidValueDetails(t, CurrentField, msgs...)
+	}
+	return nil
+}
+
+// ValidateConditionTypes validates the type of each of conds with
+// ValidateConditionType. The paths of the returned errors are relative to
+// the conditions, e.g. [1].type.
+func ValidateConditionTypes(conds Conditions) *FieldError {
+	var errs *FieldError
+	for i, c := range conds {
+		errs = errs.Also(ValidateConditionType(c.Type).ViaField("type").ViaIndex(i))
+	}
+	return errs
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsWellKnownConditionType(t *testing.T) {
+	tests := map[ConditionType]bool{
+		ConditionReady:     true,
+		ConditionSucceeded: true,
+		"ready":            false,
+		"ContainerHealthy": false,
+		"":                 false,
+	}
+	for ct, want := range tests {
+		if got := IsWellKnownConditionType(ct); got != want {
+			t.Errorf("IsWellKnownConditionType(%q) = %v, want: %v", ct, got, want)
+		}
+	}
+}
+
+func TestRegisterConditionType(t *testing.T) {
+	const ct ConditionType = "example.com/Registered"
+	if IsWellKnownConditionType(ct) {
+		t.Fatalf("IsWellKnownConditionType(%q) = true before registering", ct)
+	}
+	RegisterConditionType(ct)
+	defer func() {
+		wellKnownMu.Lock()
+		defer wellKnownMu.Unlock()
+		delete(wellKnownConditionTypes, ct)
+	}()
+	if !IsWellKnownConditionType(ct) {
+		t.Errorf("IsWellKnownConditionType(%q) = false after registering", ct)
+	}
+}
+
+func TestValidateConditionType(t *testing.T) {
+	tests := []struct {
+		name    string
+		ct      ConditionType
+		wantErr bool
+	}{{
+		name: "well-known",
+		ct:   ConditionReady,
+	}, {
+		name: "custom",
+		ct:   "ContainerHealthy",
+	}, {
+		name: "custom with prefix",
+		ct:   "example.com/ContainerHealthy",
+	}, {
+		name:    "empty",
+		ct:      "",
+		wantErr: true,
+	}, {
+		name:    "space",
+		ct:      "Container Healthy",
+		wantErr: true,
+	}, {
+		name:    "leading dash",
+		ct:      "-Ready",
+		wantErr: true,
+	}, {
+		name:    "bad prefix",
+		ct:      "Example_com/Ready",
+		wantErr: true,
+	}, {
+		name:    "too many slashes",
+		ct:      "a/b/c",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateConditionType(test.ct)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("ValidateConditionType(%q) = %v, wantErr: %v", test.ct, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConditionTypes(t *testing.T) {
+	conds := Conditions{
+		{Type: ConditionReady},
+		{Type: "Not Valid"},
+		{Type: "ContainerHealthy"},
+		{},
+	}
+	err := ValidateConditionTypes(conds)
+	if err == nil {
+		t.Fatal("ValidateConditionTypes() = nil")
+	}
+	var got []string
+	for _, e := range err.normalized() {
+		got = append(got, e.Paths...)
+	}
+	if want := []string{"[1].type", "[3].type"}; !cmp.Equal(got, want) {
+		t.Errorf("paths = %v, want: %v", got, want)
+	}
+
+	if err := ValidateConditionTypes(Conditions{{Type: ConditionSucceeded}}); err != nil {
+		t.Error("ValidateConditionTypes() =", err)
+	}
+}
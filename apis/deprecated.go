@@ -80,6 +80,35 @@ func CheckDeprecatedUpdate(ctx context.Context, obj, original interface{}) *Fiel
 	return errs
 }
 
+// CheckNewlySetDeprecatedFields checks the deprecated fields of current at
+// the given JSON paths (e.g. "spec.string") and returns a FieldError for each
+// one that is set in current but was unset in previous. Fields that were
+// already set in previous are grandfathered, even if their value changed.
+// A path that does not name a field of the type results in an
+// "Internal Error" for that path.
+func CheckNewlySetDeprecatedFields(current, previous interface{}, paths ...string) *FieldError {
+	var errs *FieldError
+	for _, path := range paths {
+		cur, err := fieldByJSONPath(reflect.ValueOf(current), path)
+		if err != nil {
+			errs = errs.Also(errBadPath(path, err))
+			continue
+		}
+		if !nonZero(reflect.ValueOf(cur)) {
+			continue
+		}
+		prev, err := fieldByJSONPath(reflect.ValueOf(previous), path)
+		if err != nil {
+			errs = errs.Also(errBadPath(path, err))
+			continue
+		}
+		if !nonZero(reflect.ValueOf(prev)) {
+			errs = errs.Also(ErrDisallowedUpdateDeprecatedFields(path))
+		}
+	}
+	return errs
+}
+
 func getPrefixedNamedFieldValues(prefix string, obj interface{}) (map[string]reflect.Value, map[string]interface{}) {
 	fields := map[string]reflect.Value{}
 	inlined := map[string]interface{}{}
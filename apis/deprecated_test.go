@@ -512,3 +512,71 @@ func TestCheckDeprecatedUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckNewlySetDeprecatedFields(t *testing.T) {
+	paths := []string{"string", "stringPtr", "intPtr", "map", "slice", "structPtr"}
+
+	testCases := map[string]struct {
+		obj  interface{}
+		org  interface{}
+		want *apis.FieldError
+	}{
+		"nothing set": {
+			org: &InnerDefaultSubSpec{},
+			obj: &InnerDefaultSubSpec{},
+		},
+		"newly set": {
+			org: &InnerDefaultSubSpec{},
+			obj: &InnerDefaultSubSpec{
+				DeprecatedString:    "new",
+				DeprecatedIntPtr:    ptr.Int64(42),
+				DeprecatedMap:       map[string]string{"hello": "failure"},
+				DeprecatedStructPtr: &InnerDefaultStruct{FieldAsString: "new"},
+			},
+			want: apis.ErrDisallowedUpdateDeprecatedFields("string", "intPtr", "map", "structPtr"),
+		},
+		"previously set and changed": {
+			org: &InnerDefaultSubSpec{
+				DeprecatedString:    "old",
+				DeprecatedStringPtr: ptr.String("old"),
+				DeprecatedSlice:     []string{"old"},
+			},
+			obj: &InnerDefaultSubSpec{
+				DeprecatedString:    "new",
+				DeprecatedStringPtr: ptr.String("new"),
+				DeprecatedSlice:     []string{"new"},
+			},
+		},
+		"previously set and one newly set": {
+			org: &InnerDefaultSubSpec{
+				DeprecatedString: "old",
+			},
+			obj: &InnerDefaultSubSpec{
+				DeprecatedString: "old",
+				DeprecatedSlice:  []string{"new"},
+			},
+			want: apis.ErrDisallowedUpdateDeprecatedFields("slice"),
+		},
+		"unset": {
+			org: &InnerDefaultSubSpec{
+				DeprecatedString: "old",
+			},
+			obj: &InnerDefaultSubSpec{},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got := apis.CheckNewlySetDeprecatedFields(tc.obj, tc.org, paths...)
+			if got.Error() != tc.want.Error() {
+				t.Errorf("CheckNewlySetDeprecatedFields() = %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckNewlySetDeprecatedFieldsBadPath(t *testing.T) {
+	got := apis.CheckNewlySetDeprecatedFields(&InnerDefaultSubSpec{DeprecatedString: "new"}, &InnerDefaultSubSpec{}, "nope")
+	if got == nil || !strings.Contains(got.Error(), "Internal Error: nope") {
+		t.Errorf("CheckNewlySetDeprecatedFields() = %v, want an Internal Error for nope", got)
+	}
+}
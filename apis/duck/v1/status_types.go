@@ -18,6 +18,7 @@ package v1
 
 import (
 	"context"
+	"strconv"
 
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/apis/duck/ducktypes"
@@ -66,6 +67,65 @@ func (s *Status) SetConditions(c apis.Conditions) {
 	s.Conditions = Conditions(c)
 }
 
+var _ apis.ObservedGenerationAccessor = (*Status)(nil)
+
+// GetObservedGeneration implements apis.ObservedGenerationAccessor
+func (s *Status) GetObservedGeneration() int64 {
+	return s.ObservedGeneration
+}
+
+// SetObservedGeneration implements apis.ObservedGenerationAccessor
+func (s *Status) SetObservedGeneration(generation int64) {
+	s.ObservedGeneration = generation
+}
+
+// TransitionCountAnnotationPrefix prefixes the condition type in the keys of
+// the status annotations that hold the transition counts, e.g.
+// "transitions.knative.dev/Ready".
+const TransitionCountAnnotationPrefix = "transitions.knative.dev/"
+
+// TransitionCountingStatus is a Status whose condition transitions are
+// counted in its annotations. Since counting is opt-in, Status itself does
+// not implement apis.TransitionCountAccessor; manage it through
+// CountTransitions instead, e.g.
+//
+//	condSet.Manage(duckv1.CountTransitions(&s.Status))
+//
+// +k8s:deepcopy-gen=false
+type TransitionCountingStatus struct {
+	*Status
+}
+
+// CountTransitions returns s as a TransitionCountingStatus.
+func CountTransitions(s *Status) TransitionCountingStatus {
+	return TransitionCountingStatus{Status: s}
+}
+
+var _ apis.TransitionCountAccessor = TransitionCountingStatus{}
+
+// GetTransitionCount implements apis.TransitionCountAccessor
+func (s TransitionCountingStatus) GetTransitionCount(t apis.ConditionType) int {
+	count, err := strconv.Atoi(s.Annotations[TransitionCountAnnotationPrefix+string(t)])
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+// SetTransitionCount implements apis.TransitionCountAccessor. A count of 0
+// removes the annotation.
+func (s TransitionCountingStatus) SetTransitionCount(t apis.ConditionType, count int) {
+	key := TransitionCountAnnotationPrefix + string(t)
+	if count == 0 {
+		delete(s.Annotations, key)
+		return
+	}
+	if s.Annotations == nil {
+		s.Annotations = make(map[string]string, 1)
+	}
+	s.Annotations[key] = strconv.Itoa(count)
+}
+
 // Ensure KResource satisfies apis.Listable
 var _ apis.Listable = (*KResource)(nil)
 
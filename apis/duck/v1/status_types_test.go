@@ -192,3 +192,61 @@ func TestConditionSet(t *testing.T) {
 		t.Error("Annotations were not nil:", s2.Annotations)
 	}
 }
+
+func TestStatusObservedGeneration(t *testing.T) {
+	s := &Status{}
+	mgr := apis.NewLivingConditionSet().Manage(s)
+	mgr.MarkTrue(apis.ConditionReady)
+	mgr.MarkObservedGeneration(3)
+
+	if got, want := s.ObservedGeneration, int64(3); got != want {
+		t.Errorf("ObservedGeneration = %d, wanted %d", got, want)
+	}
+	if !mgr.IsCurrentAndHappy(3) {
+		t.Error("IsCurrentAndHappy(3) = false, wanted true")
+	}
+	if mgr.IsCurrentAndHappy(4) {
+		t.Error("IsCurrentAndHappy(4) = true, wanted false")
+	}
+}
+
+func TestStatusTransitionCount(t *testing.T) {
+	// Plain statuses don't count transitions.
+	s := &Status{}
+	mgr := apis.NewLivingConditionSet("Foo").Manage(s)
+	mgr.InitializeConditions()
+	mgr.MarkTrue("Foo")
+	if s.Annotations != nil {
+		t.Error("Annotations were not nil:", s.Annotations)
+	}
+
+	s = &Status{}
+	mgr = apis.NewLivingConditionSet("Foo").Manage(CountTransitions(s))
+	mgr.InitializeConditions()
+	mgr.MarkTrue("Foo")
+	mgr.MarkFalse("Foo", "Broken", "")
+
+	if got, want := mgr.TransitionCount("Foo"), 2; got != want {
+		t.Errorf("TransitionCount(Foo) = %d, wanted %d", got, want)
+	}
+	if got, want := s.Annotations[TransitionCountAnnotationPrefix+"Foo"], "2"; got != want {
+		t.Errorf("Annotations[%sFoo] = %q, wanted %q", TransitionCountAnnotationPrefix, got, want)
+	}
+
+	// The counts are carried over by ConvertTo with the other annotations.
+	s2 := &Status{}
+	s.ConvertTo(context.Background(), s2)
+	if got, want := CountTransitions(s2).GetTransitionCount(apis.ConditionReady), 2; got != want {
+		t.Errorf("s2.GetTransitionCount(Ready) = %d, wanted %d", got, want)
+	}
+
+	CountTransitions(s).SetTransitionCount("Foo", 0)
+	if _, ok := s.Annotations[TransitionCountAnnotationPrefix+"Foo"]; ok {
+		t.Error("SetTransitionCount(Foo, 0) left the annotation behind")
+	}
+
+	s.Annotations[TransitionCountAnnotationPrefix+"Bar"] = "garbage"
+	if got := CountTransitions(s).GetTransitionCount("Bar"); got != 0 {
+		t.Errorf("GetTransitionCount(Bar) = %d for an invalid annotation, wanted 0", got)
+	}
+}
@@ -84,6 +84,18 @@ func (s *Status) SetConditions(c apis.Conditions) {
 	s.Conditions = Conditions(c)
 }
 
+var _ apis.ObservedGenerationAccessor = (*Status)(nil)
+
+// GetObservedGeneration implements apis.ObservedGenerationAccessor
+func (s *Status) GetObservedGeneration() int64 {
+	return s.ObservedGeneration
+}
+
+// SetObservedGeneration implements apis.ObservedGenerationAccessor
+func (s *Status) SetObservedGeneration(generation int64) {
+	s.ObservedGeneration = generation
+}
+
 // Verify KResource resources meet duck contracts.
 var (
 	_ apis.Listable         = (*KResource)(nil)
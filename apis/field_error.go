@@ -19,6 +19,7 @@ package apis
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"knative.dev/pkg/kmp"
@@ -28,6 +29,14 @@ import (
 // a problem with the current field itself.
 const CurrentField = ""
 
+// Messages of the FieldErrors built by the helpers below, shared with
+// ToAPIMachineryErrorList which maps errors by them.
+const (
+	missingFieldMessage     = "missing field(s)"
+	disallowedFieldsMessage = "must not set the field(s)"
+	invalidValuePrefix      = "invalid value: "
+)
+
 // FieldError is used to propagate the context of errors pertaining to
 // specific fields in a manner suitable for use in a recursive walk, so
 // that errors contain the appropriate field context.
@@ -69,8 +78,15 @@ func (fe *FieldError) ViaField(prefix ...string) *FieldError {
 		newPaths = append(newPaths, flatten(append(prefix, oldPath)))
 	}
 	newErr.Paths = newPaths
+	// Collect the prefixed errors directly rather than through Also, which
+	// would copy newErr once per error.
+	if len(fe.errors) > 0 {
+		newErr.errors = make([]FieldError, 0, len(fe.errors))
+	}
 	for _, e := range fe.errors {
-		newErr = newErr.Also(e.ViaField(prefix...))
+		if ve := e.ViaField(prefix...); !ve.isEmpty() {
+			newErr.errors = append(newErr.errors, *ve)
+		}
 	}
 	return newErr
 }
@@ -107,6 +123,35 @@ func (fe *FieldError) ViaFieldKey(field, key string) *FieldError {
 	return fe.ViaKey(key).ViaField(field)
 }
 
+// ViaNamespacedName is used to attribute errors to the object they were
+// found in when errors of several objects are collected together, e.g. in a
+// batch admission. It prefixes the paths with namespace/name, or just name
+// for a cluster-scoped object:
+//   err.ViaField("spec").ViaNamespacedName("default", "my-obj")
+// renders as default/my-obj.spec.foo for an error at foo.
+func (fe *FieldError) ViaNamespacedName(namespace, name string) *FieldError {
+	if namespace != "" {
+		name = namespace + "/" + name
+	}
+	return fe.ViaField(name)
+}
+
+// Index returns the path segment of the element at index in a collection.
+// Together with Key it lets ViaField build a deep path in one call:
+//   err.ViaField("spec", Index(0), "items", Key("a"))
+// is the same as
+//   err.ViaFieldKey("items", "a").ViaFieldIndex("spec", 0)
+// and both render as spec[0].items[a].
+func Index(index int) string {
+	return asIndex(index)
+}
+
+// Key returns the path segment of the entry for key in a map, escaped the
+// same way as by ViaKey. See Index for how to use it with ViaField.
+func Key(key string) string {
+	return asKey(key)
+}
+
 // Also collects errors, returns a new collection of existing errors and new errors.
 func (fe *FieldError) Also(errs ...*FieldError) *FieldError {
 	// Avoid doing any work, if we don't have to.
@@ -114,17 +159,23 @@ func (fe *FieldError) Also(errs ...*FieldError) *FieldError {
 		return fe
 	}
 
-	var newErr *FieldError
-	// collect the current objects errors, if it has any
+	newErr := &FieldError{}
+	// collect the current objects errors, if it has any. The paths and the
+	// list of errors are copied, so that neither changing the paths of the
+	// result nor appending to it writes into fe. The nested errors are
+	// unexported and never modified, so they are shared.
 	if !fe.isEmpty() {
-		newErr = fe.DeepCopy()
-	} else {
-		newErr = &FieldError{}
+		*newErr = *fe
+		newErr.Paths = append([]string(nil), fe.Paths...)
+		newErr.errors = make([]FieldError, len(fe.errors), len(fe.errors)+len(errs))
+		copy(newErr.errors, fe.errors)
 	}
 	// and then collect the passed in errors
 	for _, e := range errs {
 		if !e.isEmpty() {
-			newErr.errors = append(newErr.errors, *e)
+			c := *e
+			c.Paths = append([]string(nil), e.Paths...)
+			newErr.errors = append(newErr.errors, c)
 		}
 	}
 	if newErr.isEmpty() {
@@ -133,6 +184,35 @@ func (fe *FieldError) Also(errs ...*FieldError) *FieldError {
 	return newErr
 }
 
+// Merge returns a new FieldError holding the errors of both fe and other,
+// with errors that have the same Message and Details combined into one error
+// carrying the union of their paths. All other errors are kept as they are.
+// Unlike Also, which only collects errors and leaves the combining to Error,
+// the result of Merge is already flat and deduplicated. Merge returns nil
+// when neither fe nor other holds an error.
+func (fe *FieldError) Merge(other *FieldError) *FieldError {
+	merged := merge(append(fe.normalized(), other.normalized()...))
+	switch len(merged) {
+	case 0:
+		return nil
+	case 1:
+		return merged[0]
+	}
+	newErr := &FieldError{}
+	for _, e := range merged {
+		newErr.errors = append(newErr.errors, *e)
+	}
+	return newErr
+}
+
+// CombineFieldErrors folds errs into a single FieldError via Also.
+// Nil and empty entries are skipped, and nil is returned when none of errs
+// carries an error.
+func CombineFieldErrors(errs ...*FieldError) *FieldError {
+	var fe *FieldError
+	return fe.Also(errs...)
+}
+
 func (fe *FieldError) isEmpty() bool {
 	if fe == nil {
 		return true
@@ -165,11 +245,105 @@ func (fe *FieldError) normalized() []*FieldError {
 	return errors
 }
 
+// HasPath returns true if any of the errors collected in fe implicates the
+// given path, e.g. "spec.template.containers[0].image". Paths are compared
+// after flattening, so the result does not depend on how the error was built
+// up with ViaField, ViaIndex and ViaKey. Keys in path may be given as is,
+// e.g. "labels[a]b]", or escaped as by Key, e.g. "labels" + Key("a]b").
+func (fe *FieldError) HasPath(path string) bool {
+	flat := flatten([]string{path})
+	for _, e := range fe.normalized() {
+		for _, p := range e.Paths {
+			if p = flatten([]string{p}); p == flat || unescapeKey(p) == path {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Assert returns an error naming the errors collected in fe that do not
+// implicate any path, e.g. a leaf error that was never passed through
+// ViaField, and so render as "message: ". It is meant for tests of
+// validation code and does not change how fe renders.
+func (fe *FieldError) Assert() error {
+	var bad []string
+	for _, e := range fe.normalized() {
+		if flatten(e.Paths) == "" {
+			bad = append(bad, strconv.Quote(e.Message))
+		}
+	}
+	if len(bad) > 0 {
+		return fmt.Errorf("errors without paths: %s", strings.Join(bad, ", "))
+	}
+	return nil
+}
+
+// Under returns the errors collected in fe that are at or below the path
+// made of prefix, re-rooted there, i.e. with the prefix stripped from their
+// paths. It is the inverse of ViaField:
+//   err.ViaField("spec", "template").Under("spec", "template")
+// holds the errors of err. Paths of an error outside of prefix are dropped,
+// as are errors with no path left. It returns nil if nothing matches.
+func (fe *FieldError) Under(prefix ...string) *FieldError {
+	pre := flatten(prefix)
+	var errs *FieldError
+	for _, e := range fe.normalized() {
+		var paths []string
+		for _, p := range e.Paths {
+			p = flatten([]string{p})
+			switch {
+			case pre == "":
+				paths = append(paths, p)
+			case p == pre:
+				paths = append(paths, CurrentField)
+			case strings.HasPrefix(p, pre+"."):
+				paths = append(paths, p[len(pre)+1:])
+			case strings.HasPrefix(p, pre+"["):
+				paths = append(paths, p[len(pre):])
+			}
+		}
+		if len(paths) > 0 {
+			errs = errs.Also(&FieldError{
+				Message: e.Message,
+				Paths:   paths,
+				Details: e.Details,
+			})
+		}
+	}
+	return errs
+}
+
 // Error implements error
 func (fe *FieldError) Error() string {
+	return fe.ErrorN(0)
+}
+
+// ErrorN renders the errors like Error, but at most max of them in the same
+// order. If there are more, it appends a line "... and K more" for the K
+// errors left out. A max of zero or less renders all errors.
+func (fe *FieldError) ErrorN(max int) string {
 	// Get the list of errors as a flat merged list.
-	normedErrors := merge(fe.normalized())
-	errs := make([]string, 0, len(normedErrors))
+	return render(merge(fe.normalized()), max)
+}
+
+// ErrorOrdered renders the errors like Error, but in the order they were
+// reported with Also rather than sorted by message. Errors with the same
+// message and details are still merged, at the place of the first of them,
+// and their paths are kept in the order they were reported.
+func (fe *FieldError) ErrorOrdered() string {
+	return render(mergeOrdered(fe.normalized()), 0)
+}
+
+// render formats the flat list of errors as one line per error, followed by
+// its details if any, truncating the list as described by ErrorN.
+func render(normedErrors []*FieldError, max int) string {
+	more := 0
+	if max > 0 && len(normedErrors) > max {
+		more = len(normedErrors) - max
+		normedErrors = normedErrors[:max]
+	}
+	errs := make([]string, 0, len(normedErrors)+1)
 	for _, e := range normedErrors {
 		if e.Details == "" {
 			errs = append(errs, fmt.Sprintf("%v: %v", e.Message, strings.Join(e.Paths, ", ")))
@@ -177,6 +351,9 @@ func (fe *FieldError) Error() string {
 			errs = append(errs, fmt.Sprintf("%v: %v\n%v", e.Message, strings.Join(e.Paths, ", "), e.Details))
 		}
 	}
+	if more > 0 {
+		errs = append(errs, fmt.Sprintf("... and %d more", more))
+	}
 	return strings.Join(errs, "\n")
 }
 
@@ -190,8 +367,23 @@ func isIndex(part string) bool {
 	return strings.HasPrefix(part, "[") && strings.HasSuffix(part, "]")
 }
 
+// keyEscaper percent-encodes the characters that would make a key ambiguous
+// inside its brackets, keyUnescaper reverses it.
+var (
+	keyEscaper   = strings.NewReplacer("%", "%25", "[", "%5B", "]", "%5D")
+	keyUnescaper = strings.NewReplacer("%25", "%", "%5B", "[", "%5D", "]")
+)
+
+// asKey wraps key in brackets. Brackets and percent signs within key are
+// percent-encoded, so ViaKey("a]b") renders as [a%5Db] and the path can be
+// split and parsed back unambiguously.
 func asKey(key string) string {
-	return fmt.Sprintf("[%s]", key)
+	return "[" + keyEscaper.Replace(key) + "]"
+}
+
+// unescapeKey returns the key that asKey encoded as [key].
+func unescapeKey(key string) string {
+	return keyUnescaper.Replace(key)
 }
 
 // flatten takes in a array of path components and looks for chances to flatten
@@ -200,10 +392,17 @@ func asKey(key string) string {
 //   err(bar).ViaIndex(0).ViaField(foo) -> foo.[0].bar converts to foo[0].bar
 //   err(bar).ViaField(foo).ViaIndex(0) -> [0].foo.bar converts to [0].foo.bar
 //   err(bar).ViaIndex(0).ViaIndex(1).ViaField(foo) -> foo.[1].[0].bar converts to foo[1][0].bar
+// Empty segments (CurrentField) are dropped wherever they appear, so the
+// result never has leading, trailing or consecutive dots outside of brackets.
+//
+// Brackets group: a dot between "[" and the matching "]" does not separate
+// segments, so a key containing dots, e.g. err.ViaKey("app.kubernetes.io/name"),
+// stays a single key: labels[app.kubernetes.io/name]. An unmatched "[" groups
+// the rest of its part.
 func flatten(path []string) string {
 	var newPath []string
 	for _, part := range path {
-		for _, p := range strings.Split(part, ".") {
+		for _, p := range splitPath(part) {
 			switch {
 			case p == CurrentField:
 				continue
@@ -217,17 +416,31 @@ func flatten(path []string) string {
 	return strings.Join(newPath, ".")
 }
 
-// mergePaths takes in two string slices and returns the combination of them
-// without any duplicate entries.
-func mergePaths(a, b []string) []string {
-	newPaths := make([]string, 0, len(a)+len(b))
-	newPaths = append(newPaths, a...)
-	for _, bi := range b {
-		if !containsString(newPaths, bi) {
-			newPaths = append(newPaths, bi)
+// splitPath splits a path into its dot separated segments, keeping any index
+// or key attached to the segment before it. Dots within brackets, e.g. in
+// labels[app.kubernetes.io/name], do not split.
+func splitPath(path string) []string {
+	var segments []string
+	depth, start := 0, 0
+	for i, c := range path {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		case '.':
+			if depth == 0 {
+				segments = append(segments, path[start:i])
+				start = i + 1
+			}
 		}
 	}
-	return newPaths
+	if start < len(path) {
+		segments = append(segments, path[start:])
+	}
+	return segments
 }
 
 // containsString takes in a string slice and looks for the provided string
@@ -246,32 +459,15 @@ func containsString(slice []string, s string) bool {
 // Message and Details are the same. Merge will not inspect FieldError.errors.
 // Merge will also sort the .Path slice, and the errors slice before returning.
 func merge(errs []*FieldError) []*FieldError {
-	// make a map big enough for all the errors.
-	m := make(map[string]*FieldError, len(errs))
-
-	// Convert errs to a map where the key is <message>-<details> and the value
-	// is the error. If an error already exists in the map with the same key,
-	// then the paths will be merged.
-	for _, e := range errs {
-		k := key(e)
-		if v, ok := m[k]; ok {
-			// Found a match, merge the keys.
-			v.Paths = mergePaths(v.Paths, e.Paths)
-		} else {
-			// Does not exist in the map, save the error.
-			m[k] = e
-		}
+	newErrs := mergeOrdered(errs)
+	for _, v := range newErrs {
+		// While we have access to the merged paths, sort them too. They may
+		// still be shared with the error they came from, so sort a copy.
+		v.Paths = append([]string(nil), v.Paths...)
+		sort.Strings(v.Paths)
 	}
 
-	// Take the map made previously and flatten it back out again.
-	newErrs := make([]*FieldError, 0, len(m))
-	for _, v := range m {
-		// While we have access to the merged paths, sort them too.
-		sort.Slice(v.Paths, func(i, j int) bool { return v.Paths[i] < v.Paths[j] })
-		newErrs = append(newErrs, v)
-	}
-
-	// Sort the flattened map.
+	// Sort the flattened list.
 	sort.Slice(newErrs, func(i, j int) bool {
 		if newErrs[i].Message == newErrs[j].Message {
 			return newErrs[i].Details < newErrs[j].Details
@@ -283,9 +479,57 @@ func merge(errs []*FieldError) []*FieldError {
 	return newErrs
 }
 
+// mergeOrdered combines FieldErrors like merge, but keeps them and their
+// Paths in the order they first appear in errs.
+func mergeOrdered(errs []*FieldError) []*FieldError {
+	// make a map big enough for all the errors.
+	m := make(map[errKey]*FieldError, len(errs))
+	newErrs := make([]*FieldError, 0, len(errs))
+	// seen holds the paths of the errors that were merged into, so that
+	// merging many errors stays linear in the number of paths.
+	seen := make(map[errKey]map[string]struct{})
+
+	// Index errs by message and details. If an error already exists in the map
+	// with the same key, then the paths will be merged.
+	for _, e := range errs {
+		k := key(e)
+		v, ok := m[k]
+		if !ok {
+			// Does not exist in the map, save the error.
+			m[k] = e
+			newErrs = append(newErrs, e)
+			continue
+		}
+		// Found a match, merge the paths without any duplicate entries.
+		paths, ok := seen[k]
+		if !ok {
+			paths = make(map[string]struct{}, len(v.Paths)+len(e.Paths))
+			for _, p := range v.Paths {
+				paths[p] = struct{}{}
+			}
+			seen[k] = paths
+			v.Paths = append([]string(nil), v.Paths...)
+		}
+		for _, p := range e.Paths {
+			if _, ok := paths[p]; !ok {
+				paths[p] = struct{}{}
+				v.Paths = append(v.Paths, p)
+			}
+		}
+	}
+	return newErrs
+}
+
+// errKey identifies the errors merge combines. Keeping Message and Details
+// apart means no choice of either can make two different errors collide.
+type errKey struct {
+	message string
+	details string
+}
+
 // key returns the key using the fields .Message and .Details.
-func key(err *FieldError) string {
-	return fmt.Sprintf("%s-%s", err.Message, err.Details)
+func key(err *FieldError) errKey {
+	return errKey{message: err.Message, details: err.Details}
 }
 
 // Public helpers ---
@@ -294,7 +538,7 @@ func key(err *FieldError) string {
 // a set of missing fields.
 func ErrMissingField(fieldPaths ...string) *FieldError {
 	return &FieldError{
-		Message: "missing field(s)",
+		Message: missingFieldMessage,
 		Paths:   fieldPaths,
 	}
 }
@@ -303,7 +547,7 @@ func ErrMissingField(fieldPaths ...string) *FieldError {
 // for a set of disallowed fields.
 func ErrDisallowedFields(fieldPaths ...string) *FieldError {
 	return &FieldError{
-		Message: "must not set the field(s)",
+		Message: disallowedFieldsMessage,
 		Paths:   fieldPaths,
 	}
 }
@@ -327,7 +571,24 @@ func ErrInvalidArrayValue(value interface{}, field string, index int) *FieldErro
 // invalid value.
 func ErrInvalidValue(value interface{}, fieldPath string) *FieldError {
 	return &FieldError{
-		Message: fmt.Sprint("invalid value: ", value),
+		Message: fmt.Sprint(invalidValuePrefix, value),
+		Paths:   []string{fieldPath},
+	}
+}
+
+// errInvalidValueDetails is ErrInvalidValue with details, e.g. the messages
+// returned by the k8s.io/apimachinery validation helpers.
+func errInvalidValueDetails(value interface{}, fieldPath string, details ...string) *FieldError {
+	err := ErrInvalidValue(value, fieldPath)
+	err.Details = strings.Join(details, ", ")
+	return err
+}
+
+// ErrDuplicateValue constructs a FieldError for a field that repeats a value
+// that must be unique.
+func ErrDuplicateValue(value interface{}, fieldPath string) *FieldError {
+	return &FieldError{
+		Message: fmt.Sprint("duplicate value: ", value),
 		Paths:   []string{fieldPath},
 	}
 }
@@ -378,6 +639,30 @@ func ErrOutOfBoundsValue(value, lower, upper interface{}, fieldPath string) *Fie
 	}
 }
 
+// ErrImmutableField is a variadic helper method for constructing a FieldError
+// for a set of fields that must not change on update but did.
+func ErrImmutableField(fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message: "Immutable field changed",
+		Paths:   fieldPaths,
+	}
+}
+
+// ErrImmutableFieldDiff constructs a FieldError like ErrImmutableField for a
+// field that changed from old to new, with the difference in Details. If the
+// values cannot be diffed the error has no Details.
+func ErrImmutableFieldDiff(old, new interface{}, fieldPath string) *FieldError {
+	diff, err := kmp.ShortDiff(old, new)
+	if err != nil {
+		return ErrImmutableField(fieldPath)
+	}
+	return &FieldError{
+		Message: "Immutable field changed (-old +new)",
+		Paths:   []string{fieldPath},
+		Details: diff,
+	}
+}
+
 // CheckDisallowedFields compares the request object against a masked request object. Fields
 // that are set in the request object that are unset in the mask are reported back as disallowed fields. If
 // there is an error comparing the two objects FieldError of "Internal Error" is returned.
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ToAPIMachineryErrorList converts fe into a field.ErrorList with one entry
+// per path of every error collected in fe, in the same order Error() renders
+// them. The type of each entry is inferred from the message: errors built
+// with ErrMissingField become field.Required, ErrDisallowedFields become
+// field.Forbidden and everything else becomes field.Invalid.
+// A nil FieldError yields an empty list.
+func ToAPIMachineryErrorList(fe *FieldError) field.ErrorList {
+	var list field.ErrorList
+	for _, e := range merge(fe.normalized()) {
+		paths := e.Paths
+		if len(paths) == 0 {
+			paths = []string{CurrentField}
+		}
+		for _, p := range paths {
+			list = append(list, toAPIMachineryError(e, toFieldPath(p)))
+		}
+	}
+	return list
+}
+
+func toAPIMachineryError(fe *FieldError, p *field.Path) *field.Error {
+	switch {
+	case fe.Message == missingFieldMessage:
+		return field.Required(p, fe.Details)
+	case fe.Message == disallowedFieldsMessage:
+		return field.Forbidden(p, fe.Details)
+	case strings.HasPrefix(fe.Message, invalidValuePrefix):
+		return field.Invalid(p, strings.TrimPrefix(fe.Message, invalidValuePrefix), fe.Details)
+	default:
+		detail := fe.Message
+		if fe.Details != "" {
+			detail += ": " + fe.Details
+		}
+		return field.Invalid(p, "", detail)
+	}
+}
+
+// toFieldPath parses a flattened path such as "spec.items[0].labels[app]"
+// into a field.Path. Bracketed segments holding a number become indices,
+// all others become keys, unescaped as encoded by ViaKey. The empty path
+// yields a nil *field.Path, which renders as "".
+func toFieldPath(path string) *field.Path {
+	var p *field.Path
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				end = len(path)
+				path += "]"
+			}
+			sub := path[1:end]
+			path = path[end+1:]
+			if i, err := strconv.Atoi(sub); err == nil {
+				p = p.Index(i)
+			} else {
+				p = p.Key(unescapeKey(sub))
+			}
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			if p == nil {
+				p = field.NewPath(path[:end])
+			} else {
+				p = p.Child(path[:end])
+			}
+			path = path[end:]
+		}
+	}
+	return p
+}
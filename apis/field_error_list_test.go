@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestToAPIMachineryErrorList(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *FieldError
+		want field.ErrorList
+	}{{
+		name: "nil",
+	}, {
+		name: "missing field",
+		err:  ErrMissingField("name").ViaField("spec"),
+		want: field.ErrorList{
+			field.Required(field.NewPath("spec", "name"), ""),
+		},
+	}, {
+		name: "missing fields",
+		err:  ErrMissingField("foo", "bar"),
+		want: field.ErrorList{
+			field.Required(field.NewPath("bar"), ""),
+			field.Required(field.NewPath("foo"), ""),
+		},
+	}, {
+		name: "invalid value",
+		err:  ErrInvalidValue("-1", "replicas").ViaField("spec"),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("spec", "replicas"), "-1", ""),
+		},
+	}, {
+		name: "invalid array value",
+		err:  ErrInvalidArrayValue("x", "items", 2).ViaField("spec"),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("spec", "items").Index(2), "x", ""),
+		},
+	}, {
+		name: "disallowed field under a key",
+		err:  ErrDisallowedFields("value").ViaFieldKey("labels", "app.kubernetes.io/name"),
+		want: field.ErrorList{
+			field.Forbidden(field.NewPath("labels").Key("app.kubernetes.io/name").Child("value"), ""),
+		},
+	}, {
+		name: "generic with details",
+		err: &FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"spec.a", "spec.b"},
+			Details: "pick one",
+		},
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("spec", "a"), "", "expected exactly one, got both: pick one"),
+			field.Invalid(field.NewPath("spec", "b"), "", "expected exactly one, got both: pick one"),
+		},
+	}, {
+		name: "current field",
+		err:  ErrGeneric("broken"),
+		want: field.ErrorList{
+			field.Invalid(nil, "", "broken"),
+		},
+	}, {
+		name: "multiple errors",
+		err: ErrMissingField("name").Also(
+			ErrInvalidValue("foo", "kind"),
+		).ViaField("spec"),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("spec", "kind"), "foo", ""),
+			field.Required(field.NewPath("spec", "name"), ""),
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ToAPIMachineryErrorList(test.err)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error("ToAPIMachineryErrorList (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestToFieldPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{{
+		path: "",
+		want: "",
+	}, {
+		path: "spec",
+		want: "spec",
+	}, {
+		path: "spec.template.containers[0].env[1].name",
+		want: "spec.template.containers[0].env[1].name",
+	}, {
+		path: "metadata.labels[app.kubernetes.io/name]",
+		want: "metadata.labels[app.kubernetes.io/name]",
+	}, {
+		path: "[3].foo",
+		want: "[3].foo",
+	}, {
+		path: "spec.bag[unterminated",
+		want: "spec.bag[unterminated]",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			if got := toFieldPath(test.path).String(); got != test.want {
+				t.Errorf("toFieldPath(%q) = %q, want: %q", test.path, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseFieldError parses the string form of a FieldError, as rendered by
+// Error, back into a FieldError. Every line of the form "message: paths",
+// where paths is a comma separated list of field paths, starts an error, and
+// the lines up to the next such line are its details. Rendering the result
+// again gives s back, unless a details line itself looks like
+// "message: path", in which case it is read as an error of its own.
+func ParseFieldError(s string) (*FieldError, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var (
+		errs    *FieldError
+		current *FieldError
+		details []string
+	)
+	flush := func() {
+		if current != nil {
+			current.Details = strings.Join(details, "\n")
+			errs = errs.Also(current)
+		}
+	}
+	for i, line := range strings.Split(s, "\n") {
+		if fe, ok := parseFieldErrorLine(line); ok {
+			flush()
+			current, details = fe, nil
+			continue
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: want \"message: paths\", got %q", i+1, line)
+		}
+		details = append(details, line)
+	}
+	flush()
+	return errs, nil
+}
+
+// parseFieldErrorLine parses a line of the form "message: path1, path2".
+// Messages may contain ": " themselves, so the paths are what follows the
+// last one.
+func parseFieldErrorLine(line string) (*FieldError, bool) {
+	i := strings.LastIndex(line, ": ")
+	if i <= 0 || unicode.IsSpace(rune(line[0])) {
+		// Indented lines, e.g. of a diff, are details.
+		return nil, false
+	}
+	message, rest := line[:i], line[i+2:]
+	var paths []string
+	if rest != "" {
+		paths = strings.Split(rest, ", ")
+		for _, p := range paths {
+			if !isPath(p) {
+				return nil, false
+			}
+		}
+	}
+	return &FieldError{Message: message, Paths: paths}, true
+}
+
+// isPath returns true if p looks like a flattened field path, e.g.
+// spec.containers[0].env[FOO]: not empty, brackets balanced, and no spaces,
+// commas, colons or quotes outside of brackets.
+func isPath(p string) bool {
+	if p == "" {
+		return false
+	}
+	inBracket := false
+	for _, c := range p {
+		switch {
+		case c == '[':
+			if inBracket {
+				return false
+			}
+			inBracket = true
+		case c == ']':
+			if !inBracket {
+				return false
+			}
+			inBracket = false
+		case inBracket:
+		case unicode.IsSpace(c) || strings.ContainsRune(`,:"`, c):
+			return false
+		}
+	}
+	return !inBracket
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseFieldError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *FieldError
+	}{{
+		name: "nil",
+	}, {
+		name: "simple",
+		err:  ErrMissingField("spec.name"),
+	}, {
+		name: "message with colon",
+		err:  ErrInvalidValue("x", "spec.image"),
+	}, {
+		name: "no paths",
+		err:  &FieldError{Message: "invalid field(s)"},
+	}, {
+		name: "keys and indices",
+		err:  ErrMissingField("value").ViaFieldKey("labels", "a]b c.d").ViaFieldIndex("items", 3),
+	}, {
+		name: "multiple propagation with details",
+		err: (&FieldError{
+			Message: "invalid field(s)",
+			Paths:   []string{"foo", "bar"},
+			Details: `I am a long
+long
+loooong
+Body.`,
+		}).ViaField("baz", "ugh"),
+	}, {
+		name: "many errors with details",
+		err: ErrGeneric("An alpha error message", "A", "B").
+			Also(ErrGeneric("another", "head.left", "head.right")).
+			Also(&FieldError{Message: "this error", Paths: []string{"foo"}, Details: "devil is in the details"}).
+			Also(&FieldError{Message: "this error", Paths: []string{"foo"}, Details: "more details"}).
+			Also(&FieldError{Message: "invalid-value", Paths: []string{"bar"}, Details: "x"}).
+			ViaField("this"),
+	}, {
+		name: "immutable field diff",
+		err: (&FieldError{
+			Message: "Immutable field changed (-old +new)",
+			Paths:   []string{"spec.image"},
+			Details: "{string}:\n\t-: \"busybox\"\n\t+: \"alpine\"\n",
+		}),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := test.err.Error()
+			got, err := ParseFieldError(s)
+			if err != nil {
+				t.Fatalf("ParseFieldError(%q) = %v", s, err)
+			}
+			if test.err == nil {
+				if got != nil {
+					t.Errorf("ParseFieldError(%q) = %v, want nil", s, got)
+				}
+				return
+			}
+			if got.Error() != s {
+				t.Errorf("ParseFieldError(%q).Error() = %q", s, got.Error())
+			}
+			if diff := cmp.Diff(merge(test.err.normalized()), merge(got.normalized()), cmp.AllowUnexported(FieldError{})); diff != "" {
+				t.Error("ParseFieldError (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestParseFieldErrorInvalid(t *testing.T) {
+	for _, s := range []string{
+		"no colon at all",
+		"\tindented: foo",
+		"bad paths: not a path",
+		": foo",
+	} {
+		if got, err := ParseFieldError(s); err == nil {
+			t.Errorf("ParseFieldError(%q) = %v, want error", s, got)
+		}
+	}
+}
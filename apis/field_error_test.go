@@ -17,12 +17,16 @@ limitations under the License.
 package apis
 
 import (
+	"fmt"
+	"math/rand"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type testStruct struct {
@@ -515,6 +519,356 @@ can not use @, do not try`,
 	}
 }
 
+func TestViaFieldDeepPath(t *testing.T) {
+	err := ErrMissingField("name")
+	tests := []struct {
+		name string
+		got  *FieldError
+		want *FieldError
+	}{{
+		name: "index and key",
+		got:  err.ViaField("spec", Index(0), "items", Key("a")),
+		want: err.ViaFieldKey("items", "a").ViaFieldIndex("spec", 0),
+	}, {
+		name: "consecutive indices",
+		got:  err.ViaField("foo", Index(1), Index(2), Index(3)),
+		want: err.ViaIndex(3).ViaIndex(2).ViaIndex(1).ViaField("foo"),
+	}, {
+		name: "keys and indices",
+		got:  err.ViaField("boo", Key("A"), "bar", "foo", Index(0), "baz"),
+		want: err.ViaField("baz").ViaFieldIndex("foo", 0).ViaField("bar").ViaFieldKey("boo", "A"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got, want := test.got.Error(), test.want.Error(); got != want {
+				t.Errorf("got: %q, want %q", got, want)
+			}
+		})
+	}
+
+	if got, want := err.ViaField("spec", Index(0), "items", Key("a")).Error(), "missing field(s): spec[0].items[a].name"; got != want {
+		t.Errorf("got: %q, want %q", got, want)
+	}
+}
+
+func TestViaFieldEmptySegments(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *FieldError
+		want string
+	}{{
+		name: "empty prefix in the middle of a chain",
+		err:  ErrMissingField("foo").ViaField(CurrentField).ViaField("spec"),
+		want: "missing field(s): spec.foo",
+	}, {
+		name: "empty prefixes interleaved in a single call",
+		err:  ErrMissingField("foo").ViaField(CurrentField, "spec", CurrentField, "template", CurrentField),
+		want: "missing field(s): spec.template.foo",
+	}, {
+		name: "empty prefix around an index",
+		err:  ErrMissingField("name").ViaField(CurrentField).ViaIndex(2).ViaField(CurrentField).ViaField("items"),
+		want: "missing field(s): items[2].name",
+	}, {
+		name: "empty leaf and empty prefixes",
+		err:  ErrMissingField(CurrentField).ViaField(CurrentField).ViaField("spec").ViaField(CurrentField),
+		want: "missing field(s): spec",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.err.Error()
+			if got != test.want {
+				t.Errorf("Error() = %q, want: %q", got, test.want)
+			}
+			if strings.Contains(got, "..") || strings.HasSuffix(got, ".") || strings.Contains(got, " .") {
+				t.Errorf("Error() = %q contains an empty path segment", got)
+			}
+		})
+	}
+}
+
+func TestErrImmutableField(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *FieldError
+		want string
+	}{{
+		name: "single field",
+		err:  ErrImmutableField("image").ViaField("spec"),
+		want: "Immutable field changed: spec.image",
+	}, {
+		name: "several fields",
+		err:  ErrImmutableField("image", "name").ViaFieldIndex("containers", 1).ViaField("spec"),
+		want: "Immutable field changed: spec.containers[1].image, spec.containers[1].name",
+	}, {
+		name: "current field",
+		err:  ErrImmutableField(CurrentField).ViaFieldKey("labels", "app").ViaField("metadata"),
+		want: "Immutable field changed: metadata.labels[app]",
+	}, {
+		name: "with diff",
+		err:  ErrImmutableFieldDiff("busybox", "alpine", "image").ViaField("spec"),
+		want: "Immutable field changed (-old +new): spec.image\n" +
+			"{string}:\n\t-: \"busybox\"\n\t+: \"alpine\"\n",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.err.Error(); got != test.want {
+				t.Errorf("Error() = %q, want: %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestViaNamespacedName(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *FieldError
+		want string
+	}{{
+		name: "namespaced",
+		err:  ErrMissingField("foo").ViaField("spec").ViaNamespacedName("default", "my-obj"),
+		want: "missing field(s): default/my-obj.spec.foo",
+	}, {
+		name: "cluster-scoped",
+		err:  ErrMissingField("foo").ViaField("spec").ViaNamespacedName("", "my-obj"),
+		want: "missing field(s): my-obj.spec.foo",
+	}, {
+		name: "name with dots, index below",
+		err:  ErrInvalidValue("x", CurrentField).ViaIndex(0).ViaField("items").ViaNamespacedName("ns", "my.obj"),
+		want: "invalid value: x: ns/my.obj.items[0]",
+	}, {
+		name: "current field",
+		err:  ErrInvalidValue("x", CurrentField).ViaNamespacedName("ns", "obj"),
+		want: "invalid value: x: ns/obj",
+	}, {
+		name: "several objects",
+		err: ErrMissingField("spec.foo").ViaNamespacedName("ns", "a").
+			Also(ErrMissingField("spec.foo").ViaNamespacedName("ns", "b")),
+		want: "missing field(s): ns/a.spec.foo, ns/b.spec.foo",
+	}, {
+		name: "nil",
+		err:  (*FieldError)(nil).ViaNamespacedName("ns", "obj"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.err.Error(); got != test.want {
+				t.Errorf("Error() = %q, want: %q", got, test.want)
+			}
+		})
+	}
+
+	err := ErrMissingField("foo").ViaField("spec").ViaNamespacedName("default", "my-obj")
+	if !err.HasPath("default/my-obj.spec.foo") {
+		t.Error("HasPath(default/my-obj.spec.foo) = false")
+	}
+	if got, want := err.Under("default/my-obj").Error(), "missing field(s): spec.foo"; got != want {
+		t.Errorf("Under() = %q, want: %q", got, want)
+	}
+}
+
+func TestAssert(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     *FieldError
+		wantErr string
+	}{{
+		name: "nil",
+	}, {
+		name: "with paths",
+		err:  ErrMissingField("foo").Also(ErrInvalidValue("x", CurrentField).ViaField("bar")),
+	}, {
+		name: "no paths",
+		err: &FieldError{
+			Message: "invalid field(s)",
+			Paths:   nil,
+		},
+		wantErr: `errors without paths: "invalid field(s)"`,
+	}, {
+		name:    "current field not propagated",
+		err:     ErrInvalidValue("x", CurrentField),
+		wantErr: `errors without paths: "invalid value: x"`,
+	}, {
+		name:    "nested",
+		err:     ErrMissingField("foo").Also(ErrGeneric("oops").Also(ErrMissingField(CurrentField, CurrentField))).ViaIndex(0),
+		wantErr: `errors without paths: "oops"`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.err.Assert()
+			if test.wantErr == "" {
+				if err != nil {
+					t.Error("Assert() =", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("Assert() = %v, want: %s", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnder(t *testing.T) {
+	err := ErrMissingField("image").ViaField("spec", "template", "containers", Index(0)).
+		Also(ErrInvalidValue("x", "spec.template.name")).
+		Also(ErrDisallowedFields("spec.template")).
+		Also(ErrMissingField("spec.replicas", "spec.template.labels")).
+		Also(ErrGeneric("bad", "spec.templates")).
+		Also(ErrMissingField("status.ready"))
+
+	tests := []struct {
+		name   string
+		err    *FieldError
+		prefix []string
+		want   *FieldError
+	}{{
+		name:   "nil",
+		prefix: []string{"spec"},
+	}, {
+		name:   "subset",
+		err:    err,
+		prefix: []string{"spec", "template"},
+		want: ErrMissingField("containers[0].image", "labels").
+			Also(ErrInvalidValue("x", "name")).
+			Also(ErrDisallowedFields(CurrentField)),
+	}, {
+		name:   "flattened prefix",
+		err:    err,
+		prefix: []string{"spec.template", "containers"},
+		want:   ErrMissingField("[0].image"),
+	}, {
+		name:   "index prefix",
+		err:    err,
+		prefix: []string{"spec", "template", "containers", Index(0)},
+		want:   ErrMissingField("image"),
+	}, {
+		name:   "no match",
+		err:    err,
+		prefix: []string{"metadata"},
+	}, {
+		name:   "partial segment does not match",
+		err:    ErrMissingField("spec.templates"),
+		prefix: []string{"spec", "template"},
+	}, {
+		name:   "empty prefix",
+		err:    err,
+		prefix: nil,
+		want:   err,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.err.Under(test.prefix...)
+			if (got == nil) != (test.want == nil) {
+				t.Fatalf("Under() = %v, want: %v", got, test.want)
+			}
+			if got != nil && got.Error() != test.want.Error() {
+				t.Errorf("Under() = %q, want: %q", got.Error(), test.want.Error())
+			}
+		})
+	}
+
+	// Under undoes ViaField.
+	if got, want := err.ViaField("wrapped").Under("wrapped").Error(), err.Error(); got != want {
+		t.Errorf("ViaField().Under() = %q, want: %q", got, want)
+	}
+}
+
+func TestErrorOrdered(t *testing.T) {
+	err := ErrMissingField("spec.name").
+		Also(ErrInvalidValue("x", "spec.image")).
+		Also(ErrDisallowedFields("spec.debug")).
+		Also(ErrMissingField("spec.b", "spec.a").ViaField("nested")).
+		Also(ErrGeneric("bad", "spec.port").Also(ErrInvalidKeyName("k", "spec.labels", "details")))
+
+	want := `missing field(s): spec.name, nested.spec.b, nested.spec.a
+invalid value: x: spec.image
+must not set the field(s): spec.debug
+bad: spec.port
+invalid key name "k": spec.labels
+details`
+	if got := err.ErrorOrdered(); got != want {
+		t.Errorf("ErrorOrdered() = %q, want: %q", got, want)
+	}
+
+	// The sorted rendering is unchanged.
+	wantSorted := `bad: spec.port
+invalid key name "k": spec.labels
+details
+invalid value: x: spec.image
+missing field(s): nested.spec.a, nested.spec.b, spec.name
+must not set the field(s): spec.debug`
+	if got := err.Error(); got != wantSorted {
+		t.Errorf("Error() = %q, want: %q", got, wantSorted)
+	}
+
+	// Sorting for Error() does not reorder the paths reported.
+	unsorted := ErrMissingField("b", "a")
+	_ = unsorted.Error()
+	if got, want := unsorted.ErrorOrdered(), "missing field(s): b, a"; got != want {
+		t.Errorf("ErrorOrdered() after Error() = %q, want: %q", got, want)
+	}
+
+	var nilErr *FieldError
+	if got := nilErr.ErrorOrdered(); got != "" {
+		t.Errorf("nil.ErrorOrdered() = %q, want empty", got)
+	}
+}
+
+func TestErrorN(t *testing.T) {
+	err := ErrMissingField("a").Also(
+		ErrDisallowedFields("b"),
+		ErrInvalidValue("x", "c"),
+		ErrGeneric("d failed", "d"),
+	)
+
+	tests := []struct {
+		name string
+		max  int
+		want string
+	}{{
+		name: "fewer than the cap",
+		max:  10,
+		want: err.Error(),
+	}, {
+		name: "exactly the cap",
+		max:  4,
+		want: err.Error(),
+	}, {
+		name: "more than the cap",
+		max:  2,
+		want: `d failed: d
+invalid value: x: c
+... and 2 more`,
+	}, {
+		name: "one",
+		max:  1,
+		want: `d failed: d
+... and 3 more`,
+	}, {
+		name: "no cap",
+		max:  0,
+		want: err.Error(),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := err.ErrorN(test.max); got != test.want {
+				t.Errorf("ErrorN(%d) = %q, want: %q", test.max, got, test.want)
+			}
+		})
+	}
+
+	var nilErr *FieldError
+	if got := nilErr.ErrorN(3); got != "" {
+		t.Errorf("nil.ErrorN() = %q, want empty", got)
+	}
+}
+
 func TestNilError(t *testing.T) {
 	var err *FieldError
 	if got, want := err.Error(), ""; got != want {
@@ -603,6 +957,120 @@ not without this: bar.C`,
 	}
 }
 
+func TestCombineFieldErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		errs []*FieldError
+		want string
+	}{{
+		name: "no errors",
+	}, {
+		name: "all nil",
+		errs: []*FieldError{nil, nil, nil},
+	}, {
+		name: "nil and empty",
+		errs: []*FieldError{nil, {}, nil},
+	}, {
+		name: "single",
+		errs: []*FieldError{nil, ErrMissingField("foo"), nil},
+		want: "missing field(s): foo",
+	}, {
+		name: "mixed",
+		errs: []*FieldError{
+			ErrMissingField("foo"),
+			nil,
+			ErrInvalidValue("bad", "bar"),
+			{},
+			ErrMissingField("baz").ViaField("spec"),
+		},
+		want: `invalid value: bad: bar
+missing field(s): foo, spec.baz`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fe := CombineFieldErrors(test.errs...)
+			if test.want == "" {
+				if fe != nil {
+					t.Errorf("CombineFieldErrors() = %v, wanted nil", fe)
+				}
+				return
+			}
+			if got, want := fe.Error(), test.want; got != want {
+				t.Errorf("CombineFieldErrors() = %q, wanted %q", got, want)
+			}
+		})
+	}
+}
+
+func TestHasPath(t *testing.T) {
+	fe := ErrMissingField("name").ViaFieldIndex("containers", 0).
+		Also(ErrInvalidValue("-1", "replicas")).
+		Also(ErrGeneric("bad", "foo", "bar").ViaFieldKey("labels", "a")).
+		ViaField("spec")
+
+	tests := []struct {
+		name string
+		err  *FieldError
+		path string
+		want bool
+	}{{
+		name: "nil",
+		err:  nil,
+		path: "spec",
+	}, {
+		name: "indexed leaf",
+		err:  fe,
+		path: "spec.containers[0].name",
+		want: true,
+	}, {
+		name: "unflattened query",
+		err:  fe,
+		path: "spec.containers.[0].name",
+		want: true,
+	}, {
+		name: "second path of a leaf",
+		err:  fe,
+		path: "spec.labels[a].bar",
+		want: true,
+	}, {
+		name: "nested also",
+		err:  fe,
+		path: "spec.replicas",
+		want: true,
+	}, {
+		name: "prefix is not a match",
+		err:  fe,
+		path: "spec",
+	}, {
+		name: "different index",
+		err:  fe,
+		path: "spec.containers[1].name",
+	}, {
+		name: "current field",
+		err:  ErrMissingField(CurrentField),
+		path: CurrentField,
+		want: true,
+	}, {
+		name: "unescaped key",
+		err:  ErrMissingField("value").ViaFieldKey("labels", "a[1]%"),
+		path: "labels[a[1]%].value",
+		want: true,
+	}, {
+		name: "unescaped key of another error",
+		err:  ErrMissingField("value").ViaFieldKey("labels", "a[1]%"),
+		path: "labels[a[2]%].value",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.err.HasPath(test.path); got != test.want {
+				t.Errorf("HasPath(%q) = %v, wanted %v", test.path, got, test.want)
+			}
+		})
+	}
+}
+
 func TestAlsoNil(t *testing.T) {
 	errs := &FieldError{
 		Message: "original",
@@ -702,6 +1170,23 @@ this error: this.foo
 devil is in the details
 this error: this.foo
 more details`,
+	}, {
+		// Both of these used to be keyed as "invalid-value-x".
+		name: "dashes across the message and details boundary",
+		err: &FieldError{
+			Message: "invalid-value",
+			Paths:   []string{"foo"},
+			Details: "x",
+		},
+		also: []FieldError{{
+			Message: "invalid",
+			Paths:   []string{"bar"},
+			Details: "value-x",
+		}},
+		want: `invalid: bar
+value-x
+invalid-value: foo
+x`,
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -744,6 +1229,82 @@ func TestAlsoStaysNil(t *testing.T) {
 	}
 }
 
+func TestMergeDoesNotModifyInputs(t *testing.T) {
+	fe := ErrMissingField("b", "a")
+	other := ErrMissingField("c").Also(ErrMissingField("a"))
+	wantFe, wantOther := fe.Error(), other.Error()
+
+	fe.Merge(other)
+	if got := fe.Paths; !cmp.Equal(got, []string{"b", "a"}) {
+		t.Errorf("fe.Paths = %v, want: [b a]", got)
+	}
+	if got := fe.Error(); got != wantFe {
+		t.Errorf("fe.Error() = %q, want: %q", got, wantFe)
+	}
+	if got := other.Error(); got != wantOther {
+		t.Errorf("other.Error() = %q, want: %q", got, wantOther)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   *FieldError
+		other *FieldError
+		want  *FieldError
+	}{{
+		name: "both nil",
+	}, {
+		name:  "nil receiver",
+		other: ErrMissingField("foo"),
+		want:  ErrMissingField("foo"),
+	}, {
+		name: "nil other",
+		err:  ErrMissingField("foo"),
+		want: ErrMissingField("foo"),
+	}, {
+		name:  "overlapping messages",
+		err:   ErrMissingField("foo", "bar"),
+		other: ErrMissingField("baz", "foo"),
+		want:  ErrMissingField("bar", "baz", "foo"),
+	}, {
+		name:  "same message, different details",
+		err:   ErrInvalidKeyName("a", "foo", "bad"),
+		other: ErrInvalidKeyName("a", "bar", "worse"),
+		want: &FieldError{errors: []FieldError{
+			*ErrInvalidKeyName("a", "foo", "bad"),
+			*ErrInvalidKeyName("a", "bar", "worse"),
+		}},
+	}, {
+		name:  "disjoint messages",
+		err:   ErrMissingField("foo"),
+		other: ErrDisallowedFields("bar").Also(ErrMissingField("baz")),
+		want: &FieldError{errors: []FieldError{
+			*ErrMissingField("baz", "foo"),
+			*ErrDisallowedFields("bar"),
+		}},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var before string
+			if test.err != nil {
+				before = fmt.Sprintf("%#v", *test.err)
+			}
+			got := test.err.Merge(test.other)
+			if diff := cmp.Diff(test.want, got, cmp.AllowUnexported(FieldError{})); diff != "" {
+				t.Error("Merge (-want, +got) =", diff)
+			}
+			if test.err != nil && fmt.Sprintf("%#v", *test.err) != before {
+				t.Errorf("Merge() modified the receiver: %#v, was %s", *test.err, before)
+			}
+			if got, want := got.Error(), test.err.Also(test.other).Error(); got != want {
+				t.Errorf("Merge().Error() = %q, Also().Error() = %q", got, want)
+			}
+		})
+	}
+}
+
 func TestAlsoMultiple(t *testing.T) {
 	var err *FieldError
 
@@ -801,6 +1362,22 @@ func TestFlatten(t *testing.T) {
 		name:    "err(foo).ViaField(bar).ViaIndex[0].ViaField(baz)",
 		indices: []string{"foo", "bar.[0].baz"},
 		want:    "foo.bar[0].baz",
+	}, {
+		name:    "empty segments in the middle",
+		indices: []string{"spec", CurrentField, "foo", CurrentField, "bar"},
+		want:    "spec.foo.bar",
+	}, {
+		name:    "doubled, leading and trailing dots",
+		indices: []string{".spec..foo.", "..bar.."},
+		want:    "spec.foo.bar",
+	}, {
+		name:    "empty segment before an index",
+		indices: []string{"foo", CurrentField, "[0]", CurrentField, "bar"},
+		want:    "foo[0].bar",
+	}, {
+		name:    "only empty segments",
+		indices: []string{CurrentField, ".", CurrentField},
+		want:    "",
 	}}
 
 	for _, test := range tests {
@@ -812,6 +1389,152 @@ func TestFlatten(t *testing.T) {
 	}
 }
 
+func TestSplitPath(t *testing.T) {
+	tests := map[string][]string{
+		"":                            nil,
+		"spec":                        {"spec"},
+		"spec.containers[0].image":    {"spec", "containers[0]", "image"},
+		"labels[app.kubernetes.io/a]": {"labels[app.kubernetes.io/a]"},
+		"[1].foo":                     {"[1]", "foo"},
+	}
+	for path, want := range tests {
+		if got := splitPath(path); !cmp.Equal(got, want) {
+			t.Errorf("splitPath(%q) = %q, want: %q", path, got, want)
+		}
+	}
+}
+
+func TestFlattenKeysWithDots(t *testing.T) {
+	tests := []struct {
+		name string
+		got  *FieldError
+		want string
+	}{{
+		name: "key with dots",
+		got:  ErrMissingField("value").ViaFieldKey("labels", "app.kubernetes.io/name").ViaField("metadata"),
+		want: "metadata.labels[app.kubernetes.io/name].value",
+	}, {
+		name: "key with dots as leaf",
+		got:  ErrInvalidValue("x", CurrentField).ViaFieldKey("annotations", "a.b").ViaField("metadata"),
+		want: "metadata.annotations[a.b]",
+	}, {
+		name: "key with dots via Key",
+		got:  ErrMissingField("name").ViaField("spec", "bag", Key("a.b.c"), "items", Index(1)),
+		want: "spec.bag[a.b.c].items[1].name",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.got.normalized()[0].Paths[0]; got != test.want {
+				t.Errorf("path = %q, want: %q", got, test.want)
+			}
+			if !test.got.HasPath(test.want) {
+				t.Errorf("HasPath(%q) = false", test.want)
+			}
+		})
+	}
+}
+
+func TestKeyEscaping(t *testing.T) {
+	keys := []string{
+		"a]b",
+		"a[b",
+		"[a]",
+		"a.b",
+		"a].[b",
+		"with space",
+		"100%",
+		"%5D",
+		"",
+	}
+	for _, key := range keys {
+		t.Run(key, func(t *testing.T) {
+			err := ErrMissingField("value").ViaFieldKey("labels", key).ViaField("spec")
+			path := err.normalized()[0].Paths[0]
+			if want := "spec.labels" + Key(key) + ".value"; path != want {
+				t.Errorf("path = %q, want: %q", path, want)
+			}
+			if segments := splitPath(path); len(segments) != 3 {
+				t.Errorf("splitPath(%q) = %q, want 3 segments", path, segments)
+			}
+			if !err.HasPath(path) {
+				t.Errorf("HasPath(%q) = false", path)
+			}
+			if raw := "spec.labels[" + key + "].value"; !err.HasPath(raw) {
+				t.Errorf("HasPath(%q) = false", raw)
+			}
+			if got, want := toFieldPath(path).String(), field.NewPath("spec", "labels").Key(key).Child("value").String(); got != want {
+				t.Errorf("toFieldPath(%q) = %q, want: %q", path, got, want)
+			}
+			if got := unescapeKey(strings.TrimSuffix(strings.TrimPrefix(Key(key), "["), "]")); got != key {
+				t.Errorf("unescapeKey = %q, want: %q", got, key)
+			}
+		})
+	}
+
+	// Distinct keys must not collide once escaped.
+	a := ErrMissingField("x").ViaKey("a]b").ViaField("m")
+	b := ErrMissingField("x").ViaKey("a").ViaKey("b").ViaField("m")
+	if pa, pb := a.normalized()[0].Paths[0], b.normalized()[0].Paths[0]; pa == pb {
+		t.Errorf("paths for distinct keys collide: %q", pa)
+	}
+}
+
+// TestFlattenFuzz checks that flatten does not panic on arbitrary input, and
+// that paths built from arbitrary field names, keys and indices come out the
+// way they were built, even when keys contain dots, brackets or spaces.
+func TestFlattenFuzz(t *testing.T) {
+	const alphabet = "ab.[]0 "
+	randString := func(r *rand.Rand, drop string) string {
+		b := make([]byte, 0, 8)
+		for n := r.Intn(8); len(b) < n; {
+			if ch := alphabet[r.Intn(len(alphabet))]; !strings.ContainsRune(drop, rune(ch)) {
+				b = append(b, ch)
+			}
+		}
+		return string(b)
+	}
+	f := fuzz.New().NilChance(0).NumElements(0, 5).Funcs(func(s *string, c fuzz.Continue) {
+		*s = randString(c.Rand, "")
+	})
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 10000; i++ {
+		// Malformed input must not panic.
+		var parts []string
+		f.Fuzz(&parts)
+		isIndex(flatten(parts))
+
+		// Well-formed input must survive.
+		err := ErrMissingField("leaf")
+		want := "leaf"
+		for n := r.Intn(5); n > 0; n-- {
+			switch r.Intn(3) {
+			case 0:
+				field := randString(r, ".[]")
+				if field == "" {
+					continue
+				}
+				err, want = err.ViaField(field), field+"."+want
+			case 1:
+				key := randString(r, "")
+				err, want = err.ViaKey(key), Key(key)+"."+want
+			case 2:
+				index := r.Intn(100)
+				err, want = err.ViaIndex(index), fmt.Sprintf("[%d].%s", index, want)
+			}
+		}
+		err, want = err.ViaField("root"), "root."+want
+		want = strings.ReplaceAll(want, ".[", "[")
+		if got := err.normalized()[0].Paths[0]; got != want {
+			t.Fatalf("path = %q, want: %q", got, want)
+		}
+		if got := flatten([]string{want}); got != want {
+			t.Fatalf("flatten(%q) = %q", want, got)
+		}
+	}
+}
+
 func makeIndex(index string) int {
 	all := strings.Split(index, ",")
 	if i, err := strconv.Atoi(all[0]); err == nil {
@@ -837,3 +1560,72 @@ func makeFieldKey(fk string) (string, string) {
 	all := strings.Split(fk, ",")
 	return all[0], all[1]
 }
+
+func TestAlsoDoesNotModifyReceiver(t *testing.T) {
+	base := ErrMissingField("a").Also(ErrMissingField("b"))
+	want := base.Error()
+
+	x := base.Also(ErrGeneric("x", "p"))
+	y := base.Also(ErrGeneric("y", "q"))
+	_ = x.ViaField("spec").Error()
+
+	if got := base.Error(); got != want {
+		t.Errorf("base.Error() = %q, want: %q", got, want)
+	}
+	if got, want := x.Error(), "missing field(s): a, b\nx: p"; got != want {
+		t.Errorf("x.Error() = %q, want: %q", got, want)
+	}
+	if got, want := y.Error(), "missing field(s): a, b\ny: q"; got != want {
+		t.Errorf("y.Error() = %q, want: %q", got, want)
+	}
+}
+
+func TestAlsoDoesNotSharePaths(t *testing.T) {
+	base := ErrMissingField("a", "b")
+	other := ErrGeneric("x", "p")
+
+	got := base.Also(other)
+	got.Paths[0] = "changed"
+	other.Paths[0] = "changed"
+
+	if want := "missing field(s): a, b"; base.Error() != want {
+		t.Errorf("base.Error() = %q, want: %q", base.Error(), want)
+	}
+	if want := "missing field(s): b, changed\nx: p"; got.Error() != want {
+		t.Errorf("got.Error() = %q, want: %q", got.Error(), want)
+	}
+}
+
+// deepFieldError builds an error the way nested Validate methods do: every
+// level reports a few errors of its own and wraps those of the level below.
+func deepFieldError(depth, width int) *FieldError {
+	var err *FieldError
+	for d := 0; d < depth; d++ {
+		for w := 0; w < width; w++ {
+			err = err.Also(ErrMissingField(fmt.Sprint("field", w)).ViaIndex(w))
+		}
+		err = err.ViaField(fmt.Sprint("level", d))
+	}
+	return err
+}
+
+func BenchmarkFieldErrorDeep(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = deepFieldError(50, 5).Error()
+	}
+}
+
+func BenchmarkFieldErrorAlso(b *testing.B) {
+	errs := make([]*FieldError, 1000)
+	for i := range errs {
+		errs[i] = ErrMissingField(fmt.Sprint("field", i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var err *FieldError
+		for _, e := range errs {
+			err = err.Also(e)
+		}
+		_ = err.ViaField("spec").Error()
+	}
+}
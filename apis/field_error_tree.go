@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"sort"
+	"strings"
+)
+
+const treeIndent = "  "
+
+// Tree renders the errors collected in fe as an indented tree grouped by
+// their common path prefixes, e.g.
+//   metadata.name: invalid value: x
+//   spec.template
+//     bar: missing field(s)
+//     foo: missing field(s)
+// Details are rendered below their error, indented one level deeper. Errors
+// for the current field are rendered first, without a path. Unlike Error,
+// the output is meant for humans scanning large objects and its layout may
+// change.
+func (fe *FieldError) Tree() string {
+	root := &treeNode{}
+	for _, e := range merge(fe.normalized()) {
+		paths := e.Paths
+		if len(paths) == 0 {
+			paths = []string{CurrentField}
+		}
+		for _, p := range paths {
+			root.insert(splitPath(flatten([]string{p})), e)
+		}
+	}
+
+	var lines []string
+	for _, e := range root.errs {
+		lines = append(lines, errorLines("", "", e)...)
+	}
+	for _, name := range root.childNames() {
+		lines = root.children[name].render(name, "", lines)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// treeNode is one path segment in the tree rendered by Tree.
+type treeNode struct {
+	errs     []*FieldError
+	children map[string]*treeNode
+}
+
+func (n *treeNode) insert(segments []string, e *FieldError) {
+	if len(segments) == 0 {
+		n.errs = append(n.errs, e)
+		return
+	}
+	if n.children == nil {
+		n.children = make(map[string]*treeNode, 1)
+	}
+	child, ok := n.children[segments[0]]
+	if !ok {
+		child = &treeNode{}
+		n.children[segments[0]] = child
+	}
+	child.insert(segments[1:], e)
+}
+
+func (n *treeNode) childNames() []string {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// render appends the lines for n, reached via name, to lines.
+func (n *treeNode) render(name, indent string, lines []string) []string {
+	// Collapse chains of segments that only lead to a single child.
+	for len(n.errs) == 0 && len(n.children) == 1 {
+		child := n.childNames()[0]
+		name, n = name+"."+child, n.children[child]
+	}
+
+	if len(n.errs) == 0 {
+		lines = append(lines, indent+name)
+	}
+	for _, e := range n.errs {
+		lines = append(lines, errorLines(indent, name+": ", e)...)
+	}
+	for _, child := range n.childNames() {
+		lines = n.children[child].render(child, indent+treeIndent, lines)
+	}
+	return lines
+}
+
+// errorLines renders e below prefix at the given indentation.
+func errorLines(indent, prefix string, e *FieldError) []string {
+	lines := []string{indent + prefix + e.Message}
+	if e.Details != "" {
+		for _, d := range strings.Split(e.Details, "\n") {
+			lines = append(lines, indent+treeIndent+d)
+		}
+	}
+	return lines
+}
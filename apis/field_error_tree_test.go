@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTree(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *FieldError
+		want string
+	}{{
+		name: "nil",
+	}, {
+		name: "single path",
+		err:  ErrMissingField("foo").ViaField("spec", "template"),
+		want: "spec.template.foo: missing field(s)",
+	}, {
+		name: "shared prefix",
+		err: ErrMissingField("foo", "bar").ViaField("template").ViaField("spec").Also(
+			ErrInvalidValue("x", "metadata.name")),
+		want: `metadata.name: invalid value: x
+spec.template
+  bar: missing field(s)
+  foo: missing field(s)`,
+	}, {
+		name: "nested prefixes",
+		err: ErrMissingField("image").ViaFieldIndex("containers", 0).Also(
+			ErrMissingField("name").ViaFieldIndex("containers", 1),
+			ErrDisallowedFields("nodeName"),
+		).ViaField("spec", "template", "spec").Also(
+			ErrGeneric("bad", CurrentField).ViaField("spec"),
+		),
+		want: `spec: bad
+  template.spec
+    containers[0].image: missing field(s)
+    containers[1].name: missing field(s)
+    nodeName: must not set the field(s)`,
+	}, {
+		name: "details and current field",
+		err: ErrGeneric("broken", CurrentField).Also(&FieldError{
+			Message: "invalid key name",
+			Paths:   []string{"labels[app.kubernetes.io/name]"},
+			Details: "line one\nline two",
+		}).ViaField("metadata").Also(ErrGeneric("top", CurrentField)),
+		want: `top
+metadata: broken
+  labels[app.kubernetes.io/name]: invalid key name
+    line one
+    line two`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, test.err.Tree()); diff != "" {
+				t.Errorf("Tree() (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
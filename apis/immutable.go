@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// CheckImmutableFields compares the fields of current and previous at the
+// given JSON paths (e.g. "spec.template.image") and returns a FieldError for
+// every field whose value changed. Fields behind a nil pointer are treated
+// as unset. A path that does not name a field of the type results in an
+// "Internal Error" for that path.
+func CheckImmutableFields(current, previous interface{}, paths ...string) *FieldError {
+	var errs *FieldError
+	for _, path := range paths {
+		cur, err := fieldByJSONPath(reflect.ValueOf(current), path)
+		if err != nil {
+			errs = errs.Also(errBadPath(path, err))
+			continue
+		}
+		prev, err := fieldByJSONPath(reflect.ValueOf(previous), path)
+		if err != nil {
+			errs = errs.Also(errBadPath(path, err))
+			continue
+		}
+		if equality.Semantic.DeepEqual(prev, cur) {
+			continue
+		}
+		errs = errs.Also(ErrImmutableFieldDiff(prev, cur, path))
+	}
+	return errs
+}
+
+// errBadPath reports a path that could not be resolved by fieldByJSONPath.
+func errBadPath(path string, err error) *FieldError {
+	return &FieldError{
+		Message: "Internal Error",
+		Paths:   []string{path},
+		Details: err.Error(),
+	}
+}
+
+// fieldByJSONPath walks v along the dot separated JSON field names of path
+// and returns the dereferenced value of the field found there, or nil if a
+// nil pointer was hit on the way.
+func fieldByJSONPath(v reflect.Value, path string) (interface{}, error) {
+	for _, name := range strings.Split(path, ".") {
+		v = reflect.Indirect(v)
+		if !v.IsValid() {
+			return nil, nil
+		}
+		if v.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%q: %v is not a struct", path, v.Type())
+		}
+		f, ok := fieldByJSONName(v, name)
+		if !ok {
+			return nil, fmt.Errorf("%q: %v has no field %q", path, v.Type(), name)
+		}
+		v = f
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface(), nil
+}
+
+// fieldByJSONName returns the field of the struct v that is serialized as
+// name, looking into inlined and embedded structs as encoding/json does.
+func fieldByJSONName(v reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		tf := v.Type().Field(i)
+		jTag := strings.Split(tf.Tag.Get("json"), ",")[0]
+		if jTag == "-" || (tf.PkgPath != "" && !tf.Anonymous) {
+			// Skipped and unexported fields are not serialized.
+			continue
+		}
+		if jTag == "" && tf.Anonymous {
+			inner := reflect.Indirect(v.Field(i))
+			if inner.Kind() == reflect.Struct {
+				if f, ok := fieldByJSONName(inner, name); ok {
+					return f, true
+				}
+			}
+			continue
+		}
+		if jTag == "" {
+			jTag = tf.Name
+		}
+		if jTag == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis_test
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+	. "knative.dev/pkg/testing"
+)
+
+func TestCheckImmutableFields(t *testing.T) {
+	base := func() *InnerDefaultResource {
+		return &InnerDefaultResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "foo",
+				Labels: map[string]string{"a": "b"},
+			},
+			Spec: InnerDefaultSpec{
+				Generation:       1,
+				FieldWithDefault: "default",
+				SubFields: &InnerDefaultSubSpec{
+					DeprecatedStringPtr: ptr.String("ptr"),
+					InlinedStruct: InlinedStruct{
+						DeprecatedField: "inlined",
+					},
+				},
+			},
+		}
+	}
+	paths := []string{
+		"metadata.name",
+		"spec.fieldWithDefault",
+		"spec.subfields.stringPtr",
+		"spec.subfields.fieldA",
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func(*InnerDefaultResource)
+		paths    []string
+		wantPath string
+		wantMsg  string
+	}{{
+		name:   "unchanged",
+		mutate: func(*InnerDefaultResource) {},
+		paths:  paths,
+	}, {
+		name: "mutable field changed",
+		mutate: func(r *InnerDefaultResource) {
+			r.Spec.Generation = 2
+			r.Labels["a"] = "c"
+		},
+		paths: paths,
+	}, {
+		name: "top level field changed",
+		mutate: func(r *InnerDefaultResource) {
+			r.Spec.FieldWithDefault = "changed"
+		},
+		paths:    paths,
+		wantPath: "spec.fieldWithDefault",
+		wantMsg:  "Immutable field changed (-old +new)",
+	}, {
+		name: "embedded metadata changed",
+		mutate: func(r *InnerDefaultResource) {
+			r.Name = "bar"
+		},
+		paths:    paths,
+		wantPath: "metadata.name",
+		wantMsg:  "Immutable field changed (-old +new)",
+	}, {
+		name: "pointer field changed",
+		mutate: func(r *InnerDefaultResource) {
+			r.Spec.SubFields.DeprecatedStringPtr = ptr.String("other")
+		},
+		paths:    paths,
+		wantPath: "spec.subfields.stringPtr",
+		wantMsg:  "Immutable field changed (-old +new)",
+	}, {
+		name: "pointer to equal value",
+		mutate: func(r *InnerDefaultResource) {
+			r.Spec.SubFields.DeprecatedStringPtr = ptr.String("ptr")
+		},
+		paths: paths,
+	}, {
+		name: "pointer field unset",
+		mutate: func(r *InnerDefaultResource) {
+			r.Spec.SubFields.DeprecatedStringPtr = nil
+		},
+		paths:    paths,
+		wantPath: "spec.subfields.stringPtr",
+		wantMsg:  "Immutable field changed (-old +new)",
+	}, {
+		name: "parent pointer unset",
+		mutate: func(r *InnerDefaultResource) {
+			r.Spec.SubFields = nil
+		},
+		paths:    []string{"spec.subfields.fieldA"},
+		wantPath: "spec.subfields.fieldA",
+		wantMsg:  "Immutable field changed (-old +new)",
+	}, {
+		name: "inlined field changed",
+		mutate: func(r *InnerDefaultResource) {
+			r.Spec.SubFields.InlinedStruct.DeprecatedField = "changed"
+		},
+		paths:    paths,
+		wantPath: "spec.subfields.fieldA",
+		wantMsg:  "Immutable field changed (-old +new)",
+	}, {
+		name:     "unknown field",
+		mutate:   func(*InnerDefaultResource) {},
+		paths:    []string{"spec.nope"},
+		wantPath: "spec.nope",
+		wantMsg:  "Internal Error",
+	}, {
+		name:     "not a struct",
+		mutate:   func(*InnerDefaultResource) {},
+		paths:    []string{"spec.fieldWithDefault.nope"},
+		wantPath: "spec.fieldWithDefault.nope",
+		wantMsg:  "Internal Error",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			previous, current := base(), base()
+			test.mutate(current)
+
+			err := apis.CheckImmutableFields(current, previous, test.paths...)
+			if test.wantMsg == "" {
+				if err != nil {
+					t.Fatal("CheckImmutableFields() =", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("CheckImmutableFields() = nil, wanted an error")
+			}
+			got := err.Error()
+			if !strings.HasPrefix(got, test.wantMsg+": "+test.wantPath) {
+				t.Errorf("CheckImmutableFields() = %q, wanted %q at %q", got, test.wantMsg, test.wantPath)
+			}
+			if !err.HasPath(test.wantPath) {
+				t.Errorf("CheckImmutableFields() does not report %q", test.wantPath)
+			}
+			if n := strings.Count(got, test.wantMsg); n != 1 {
+				t.Errorf("CheckImmutableFields() = %q, wanted exactly one error, got %d", got, n)
+			}
+		})
+	}
+}
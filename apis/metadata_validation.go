@@ -18,10 +18,12 @@ package apis
 
 import (
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -72,6 +74,30 @@ func ValidateObjectMetadata(meta metav1.Object) *FieldError {
 	return nil
 }
 
+// ValidateLabelsAndAnnotations validates that the label keys and values and
+// the annotation keys in the `metadata` stanza of the resource are valid, e.g.
+// that keys are qualified names. Like ValidateObjectMetadata, the paths of
+// the returned errors are relative to the `metadata` stanza, e.g.
+// labels[bad@key].
+func ValidateLabelsAndAnnotations(meta metav1.Object) *FieldError {
+	var errs *FieldError
+	for k, v := range meta.GetLabels() {
+		if msgs := utilvalidation.IsQualifiedName(k); len(msgs) > 0 {
+			errs = errs.Also(ErrInvalidKeyName(k, CurrentField, msgs...).ViaFieldKey("labels", k))
+		}
+		if msgs := utilvalidation.IsValidLabelValue(v); len(msgs) > 0 {
+			errs = errs.Also(errInvalidValueDetails(v, CurrentField, msgs...).ViaFieldKey("labels", k))
+		}
+	}
+	for k := range meta.GetAnnotations() {
+		// Annotation keys are validated case-insensitively, as the API server does.
+		if msgs := utilvalidation.IsQualifiedName(strings.ToLower(k)); len(msgs) > 0 {
+			errs = errs.Also(ErrInvalidKeyName(k, CurrentField, msgs...).ViaFieldKey("annotations", k))
+		}
+	}
+	return errs
+}
+
 // ValidateCreatorAndModifier validates `metadata.annotation`
 func ValidateCreatorAndModifier(oldSpec, newSpec interface{}, oldAnnotation, newAnnotation map[string]string, groupName string) *FieldError {
 	var errs *FieldError
@@ -106,6 +106,72 @@ func TestValidateObjectMetadata(t *testing.T) {
 	}
 }
 
+func TestValidateLabelsAndAnnotations(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectMeta metav1.Object
+		want       *FieldError
+	}{{
+		name: "valid",
+		objectMeta: &metav1.ObjectMeta{
+			Labels: map[string]string{
+				"app":                    "foo",
+				"app.kubernetes.io/name": "",
+			},
+			Annotations: map[string]string{
+				"serving.knative.dev/Creator": "any value @ all",
+			},
+		},
+	}, {
+		name: "invalid label key",
+		objectMeta: &metav1.ObjectMeta{
+			Labels: map[string]string{"bad@key": "foo"},
+		},
+		want: ErrInvalidKeyName("bad@key", "metadata.labels[bad@key]",
+			"name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')"),
+	}, {
+		name: "invalid label value",
+		objectMeta: &metav1.ObjectMeta{
+			Labels: map[string]string{"app": "-foo"},
+		},
+		want: &FieldError{
+			Message: "invalid value: -foo",
+			Paths:   []string{"metadata.labels[app]"},
+			Details: "a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')",
+		},
+	}, {
+		name: "invalid annotation key",
+		objectMeta: &metav1.ObjectMeta{
+			Annotations: map[string]string{"a/b/c": "foo"},
+		},
+		want: ErrInvalidKeyName("a/b/c", "metadata.annotations[a/b/c]",
+			"a qualified name must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]') with an optional DNS subdomain prefix and '/' (e.g. 'example.com/MyName')"),
+	}, {
+		name: "multiple",
+		objectMeta: &metav1.ObjectMeta{
+			Labels:      map[string]string{"bad@key": "foo", "app": "-foo"},
+			Annotations: map[string]string{"a/b/c": "foo"},
+		},
+		want: ErrInvalidKeyName("bad@key", "metadata.labels[bad@key]",
+			"name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')",
+		).Also(&FieldError{
+			Message: "invalid value: -foo",
+			Paths:   []string{"metadata.labels[app]"},
+			Details: "a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')",
+		}).Also(ErrInvalidKeyName("a/b/c", "metadata.annotations[a/b/c]",
+			"a qualified name must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]') with an optional DNS subdomain prefix and '/' (e.g. 'example.com/MyName')")),
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ValidateLabelsAndAnnotations(tc.objectMeta).ViaField("metadata")
+			if diff := cmp.Diff(tc.want.Error(), got.Error()); diff != "" {
+				t.Error("ValidateLabelsAndAnnotations (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
 type WithPod struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
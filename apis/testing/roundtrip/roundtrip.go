@@ -17,7 +17,10 @@ limitations under the License.
 package roundtrip
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/url"
 	"reflect"
@@ -195,6 +198,49 @@ func roundTripViaHub(t *testing.T, gvk schema.GroupVersionKind, scheme, hubs *ru
 	}
 }
 
+// ObjectViaJSON asserts that obj is stable when round-tripped through JSON.
+// This is effectively testing the scenario:
+//
+//    object -> json -> object -> json
+//
+// and fails the test if the two JSON documents differ, e.g. because a
+// field without omitempty injects an empty value on the way back.
+func ObjectViaJSON(t *testing.T, obj interface{}) {
+	t.Helper()
+
+	want, got, err := jsonRoundTrip(obj)
+	if err != nil {
+		t.Fatal("JSON round trip failed:", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("JSON round trip produced a diff (-want, +got): %s", cmp.Diff(string(want), string(got)))
+	}
+}
+
+// jsonRoundTrip marshals obj, unmarshals the result into a fresh value of the
+// same type and marshals that again, returning both documents.
+func jsonRoundTrip(obj interface{}) ([]byte, []byte, error) {
+	want, err := json.Marshal(obj)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal %T: %w", obj, err)
+	}
+
+	typ := reflect.TypeOf(obj)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	newObj := reflect.New(typ).Interface()
+	if err := json.Unmarshal(want, newObj); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal %T: %w", newObj, err)
+	}
+
+	got, err := json.Marshal(newObj)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal %T: %w", newObj, err)
+	}
+	return want, got, nil
+}
+
 func diff(obj1, obj2 interface{}) string {
 	// knative.dev/pkg/apis.URL is an alias to net.URL which embeds a
 	// url.Userinfo that has an unexported field
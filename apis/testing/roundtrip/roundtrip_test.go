@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package roundtrip
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	pkgtesting "knative.dev/pkg/testing"
+)
+
+func TestObjectViaJSON(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	// InnerDefaultResource does not omit an empty spec, so the first
+	// marshal already carries `spec: {}` and the round trip is stable.
+	r := &pkgtesting.InnerDefaultResource{}
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal("json.Marshal() =", err)
+	}
+	if !bytes.Contains(b, []byte(`"spec":{}`)) {
+		t.Errorf("json.Marshal() = %s, wanted an empty spec", b)
+	}
+	ObjectViaJSON(t, r)
+
+	ObjectViaJSON(t, pkgtesting.InnerDefaultResource{
+		Spec: pkgtesting.InnerDefaultSpec{
+			FieldWithDefault: "foo",
+			SubFields: &pkgtesting.InnerDefaultSubSpec{
+				DeprecatedStringPtr: ptr("bar"),
+				DeprecatedMap:       map[string]string{"a": "b"},
+			},
+		},
+	})
+}
+
+// unstable gains a character every time it goes through JSON.
+type unstable struct {
+	Value string
+}
+
+func (u unstable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.Value + "!")
+}
+
+func (u *unstable) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &u.Value)
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	want, got, err := jsonRoundTrip(&unstable{Value: "hi"})
+	if err != nil {
+		t.Fatal("jsonRoundTrip() =", err)
+	}
+	if bytes.Equal(want, got) {
+		t.Errorf("jsonRoundTrip() = %s, %s, wanted a diff", want, got)
+	}
+
+	if _, _, err := jsonRoundTrip(make(chan int)); err == nil {
+		t.Error("jsonRoundTrip(chan) = nil, wanted an error")
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CheckUnique reports every element of the slice or array values that
+// repeats an earlier element, e.g. a port listed twice. Each duplicate is
+// reported at its index, so callers only add the field:
+//   errs = errs.Also(apis.CheckUnique(spec.Ports).ViaField("ports"))
+// The elements must be of a comparable type; values of any other kind
+// result in an "Internal Error". Elements that hold interface values,
+// directly or in a field, are compared with reflect.DeepEqual, since the
+// interface values may not be comparable, e.g. when they hold a slice.
+func CheckUnique(values interface{}) *FieldError {
+	v := reflect.ValueOf(values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return &FieldError{
+			Message: "Internal Error",
+			Paths:   []string{CurrentField},
+			Details: fmt.Sprintf("%T is not a slice", values),
+		}
+	}
+	if !v.Type().Elem().Comparable() {
+		return &FieldError{
+			Message: "Internal Error",
+			Paths:   []string{CurrentField},
+			Details: fmt.Sprintf("elements of %T are not comparable", values),
+		}
+	}
+	var (
+		errs *FieldError
+		seen = make(map[interface{}]struct{}, v.Len())
+		// uncomparable holds the elements that cannot safely be map keys.
+		uncomparable []interface{}
+	)
+	for i := 0; i < v.Len(); i++ {
+		e := v.Index(i).Interface()
+		if t := reflect.TypeOf(e); t != nil && !hashable(t) {
+			if containsDeepEqual(uncomparable, e) {
+				errs = errs.Also(ErrDuplicateValue(e, CurrentField).ViaIndex(i))
+				continue
+			}
+			uncomparable = append(uncomparable, e)
+			continue
+		}
+		if _, ok := seen[e]; ok {
+			errs = errs.Also(ErrDuplicateValue(e, CurrentField).ViaIndex(i))
+			continue
+		}
+		seen[e] = struct{}{}
+	}
+	return errs
+}
+
+// hashable returns true if values of type t can be used as map keys without
+// panicking. Comparable types that contain interfaces are not, as hashing
+// panics when an interface holds an uncomparable value.
+func hashable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Array:
+		return hashable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !hashable(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return t.Comparable()
+	}
+}
+
+// containsDeepEqual returns true if values holds an element deeply equal to v.
+func containsDeepEqual(values []interface{}, v interface{}) bool {
+	for _, e := range values {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"testing"
+)
+
+func TestCheckUnique(t *testing.T) {
+	type port struct {
+		Name string
+		Port int
+	}
+	type holder struct {
+		V interface{}
+	}
+	tests := []struct {
+		name   string
+		values interface{}
+		want   string
+	}{{
+		name:   "nil",
+		values: []string(nil),
+	}, {
+		name:   "unique strings",
+		values: []string{"a", "b", "c"},
+	}, {
+		name:   "duplicate strings",
+		values: []string{"a", "b", "a", "c", "b", "a"},
+		want: "duplicate value: a: ports[2], ports[5]\n" +
+			"duplicate value: b: ports[4]",
+	}, {
+		name:   "duplicate ints",
+		values: []int32{80, 443, 80},
+		want:   "duplicate value: 80: ports[2]",
+	}, {
+		name:   "array",
+		values: [3]int{1, 1, 1},
+		want:   "duplicate value: 1: ports[1], ports[2]",
+	}, {
+		name:   "duplicate structs",
+		values: []port{{"http", 80}, {"https", 443}, {"http", 80}, {"http", 8080}},
+		want:   "duplicate value: {http 80}: ports[2]",
+	}, {
+		name:   "not a slice",
+		values: "abc",
+		want:   "Internal Error: ports\nstring is not a slice",
+	}, {
+		name:   "interfaces",
+		values: []interface{}{"a", 1, "a", nil, nil},
+		want: "duplicate value: <nil>: ports[4]\n" +
+			"duplicate value: a: ports[2]",
+	}, {
+		name:   "interfaces holding slices and maps",
+		values: []interface{}{[]int{1}, map[string]int{}, []int{1}, map[string]int{"a": 1}, map[string]int{}, []int{2}},
+		want: "duplicate value: [1]: ports[2]\n" +
+			"duplicate value: map[]: ports[4]",
+	}, {
+		name:   "structs holding slices",
+		values: []holder{{V: []int{1}}, {V: []int{2}}, {V: []int{1}}},
+		want:   "duplicate value: {[1]}: ports[2]",
+	}, {
+		name:   "arrays holding maps",
+		values: [][1]interface{}{{map[string]int{"a": 1}}, {map[string]int{"a": 1}}},
+		want:   "duplicate value: [map[a:1]]: ports[1]",
+	}, {
+		name:   "not comparable",
+		values: [][]string{{"a"}, {"a"}},
+		want:   "Internal Error: ports\nelements of [][]string are not comparable",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := CheckUnique(test.values).ViaField("ports").Error(); got != test.want {
+				t.Errorf("CheckUnique() = %q, want: %q", got, test.want)
+			}
+		})
+	}
+}
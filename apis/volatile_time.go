@@ -34,6 +34,12 @@ type VolatileTime struct {
 	Inner metav1.Time `json:",inline"`
 }
 
+// Equal reports whether t and u represent the same time instant. Unlike
+// semantic equality it does not treat differing VolatileTimes as equal.
+func (t VolatileTime) Equal(u VolatileTime) bool {
+	return t.Inner.Equal(&u.Inner)
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (t VolatileTime) MarshalJSON() ([]byte, error) {
 	return t.Inner.MarshalJSON()
@@ -96,3 +96,20 @@ func TestVolatileTimeEquality(t *testing.T) {
 		t.Error("go-cmp.Equal with opt should returned true")
 	}
 }
+
+func TestVolatileTimeEqual(t *testing.T) {
+	instant := time.Unix(1024, 36)
+	a := VolatileTime{metav1.NewTime(instant)}
+	b := VolatileTime{metav1.NewTime(instant.In(time.FixedZone("elsewhere", 3600)))}
+	c := VolatileTime{metav1.NewTime(time.Unix(2048, 36))}
+
+	if !a.Equal(b) {
+		t.Error("Equal() = false for the same instant in different zones, wanted true")
+	}
+	if a.Equal(c) {
+		t.Error("Equal() = true for different instants, wanted false")
+	}
+	if !(VolatileTime{}).Equal(VolatileTime{}) {
+		t.Error("Equal() = false for two zero times, wanted true")
+	}
+}
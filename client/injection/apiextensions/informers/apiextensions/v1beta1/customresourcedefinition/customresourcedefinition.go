@@ -50,3 +50,10 @@ func Get(ctx context.Context) v1beta1.CustomResourceDefinitionInformer {
 	}
 	return untyped.(v1beta1.CustomResourceDefinitionInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1beta1.CustomResourceDefinitionInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1beta1.CustomResourceDefinitionInformer)
+	return inf, ok
+}
@@ -54,3 +54,10 @@ func Get(ctx context.Context) externalversions.SharedInformerFactory {
 	}
 	return untyped.(externalversions.SharedInformerFactory)
 }
+
+// TryGet extracts the InformerFactory from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (externalversions.SharedInformerFactory, bool) {
+	f, ok := ctx.Value(Key{}).(externalversions.SharedInformerFactory)
+	return f, ok
+}
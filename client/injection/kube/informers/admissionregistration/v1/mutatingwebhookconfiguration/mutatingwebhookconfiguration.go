@@ -50,3 +50,10 @@ func Get(ctx context.Context) v1.MutatingWebhookConfigurationInformer {
 	}
 	return untyped.(v1.MutatingWebhookConfigurationInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1.MutatingWebhookConfigurationInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1.MutatingWebhookConfigurationInformer)
+	return inf, ok
+}
@@ -50,3 +50,10 @@ func Get(ctx context.Context) v1.ValidatingWebhookConfigurationInformer {
 	}
 	return untyped.(v1.ValidatingWebhookConfigurationInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1.ValidatingWebhookConfigurationInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1.ValidatingWebhookConfigurationInformer)
+	return inf, ok
+}
@@ -22,6 +22,7 @@ import (
 	context "context"
 
 	v1 "k8s.io/client-go/informers/apps/v1"
+	appsv1 "k8s.io/client-go/listers/apps/v1"
 	factory "knative.dev/pkg/client/injection/kube/informers/factory"
 	controller "knative.dev/pkg/controller"
 	injection "knative.dev/pkg/injection"
@@ -50,3 +51,16 @@ func Get(ctx context.Context) v1.StatefulSetInformer {
 	}
 	return untyped.(v1.StatefulSetInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1.StatefulSetInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1.StatefulSetInformer)
+	return inf, ok
+}
+
+// GetNamespacedLister returns the lister of the typed informer in the
+// context, scoped to the given namespace.
+func GetNamespacedLister(ctx context.Context, namespace string) appsv1.StatefulSetNamespaceLister {
+	return Get(ctx).Lister().StatefulSets(namespace)
+}
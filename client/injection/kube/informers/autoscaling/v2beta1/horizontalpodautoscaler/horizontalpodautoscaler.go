@@ -22,6 +22,7 @@ import (
 	context "context"
 
 	v2beta1 "k8s.io/client-go/informers/autoscaling/v2beta1"
+	autoscalingv2beta1 "k8s.io/client-go/listers/autoscaling/v2beta1"
 	factory "knative.dev/pkg/client/injection/kube/informers/factory"
 	controller "knative.dev/pkg/controller"
 	injection "knative.dev/pkg/injection"
@@ -50,3 +51,16 @@ func Get(ctx context.Context) v2beta1.HorizontalPodAutoscalerInformer {
 	}
 	return untyped.(v2beta1.HorizontalPodAutoscalerInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v2beta1.HorizontalPodAutoscalerInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v2beta1.HorizontalPodAutoscalerInformer)
+	return inf, ok
+}
+
+// GetNamespacedLister returns the lister of the typed informer in the
+// context, scoped to the given namespace.
+func GetNamespacedLister(ctx context.Context, namespace string) autoscalingv2beta1.HorizontalPodAutoscalerNamespaceLister {
+	return Get(ctx).Lister().HorizontalPodAutoscalers(namespace)
+}
@@ -22,6 +22,7 @@ import (
 	context "context"
 
 	v1beta1 "k8s.io/client-go/informers/batch/v1beta1"
+	batchv1beta1 "k8s.io/client-go/listers/batch/v1beta1"
 	factory "knative.dev/pkg/client/injection/kube/informers/factory"
 	controller "knative.dev/pkg/controller"
 	injection "knative.dev/pkg/injection"
@@ -50,3 +51,16 @@ func Get(ctx context.Context) v1beta1.CronJobInformer {
 	}
 	return untyped.(v1beta1.CronJobInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1beta1.CronJobInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1beta1.CronJobInformer)
+	return inf, ok
+}
+
+// GetNamespacedLister returns the lister of the typed informer in the
+// context, scoped to the given namespace.
+func GetNamespacedLister(ctx context.Context, namespace string) batchv1beta1.CronJobNamespaceLister {
+	return Get(ctx).Lister().CronJobs(namespace)
+}
@@ -50,3 +50,10 @@ func Get(ctx context.Context) v1.ComponentStatusInformer {
 	}
 	return untyped.(v1.ComponentStatusInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1.ComponentStatusInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1.ComponentStatusInformer)
+	return inf, ok
+}
@@ -50,3 +50,10 @@ func Get(ctx context.Context) v1.NamespaceInformer {
 	}
 	return untyped.(v1.NamespaceInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1.NamespaceInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1.NamespaceInformer)
+	return inf, ok
+}
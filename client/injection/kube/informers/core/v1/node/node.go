@@ -50,3 +50,10 @@ func Get(ctx context.Context) v1.NodeInformer {
 	}
 	return untyped.(v1.NodeInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1.NodeInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1.NodeInformer)
+	return inf, ok
+}
@@ -50,3 +50,10 @@ func Get(ctx context.Context) v1.PersistentVolumeInformer {
 	}
 	return untyped.(v1.PersistentVolumeInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1.PersistentVolumeInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1.PersistentVolumeInformer)
+	return inf, ok
+}
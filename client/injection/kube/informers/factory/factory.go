@@ -54,3 +54,10 @@ func Get(ctx context.Context) informers.SharedInformerFactory {
 	}
 	return untyped.(informers.SharedInformerFactory)
 }
+
+// TryGet extracts the InformerFactory from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (informers.SharedInformerFactory, bool) {
+	f, ok := ctx.Value(Key{}).(informers.SharedInformerFactory)
+	return f, ok
+}
@@ -50,3 +50,10 @@ func Get(ctx context.Context) v1.ClusterRoleInformer {
 	}
 	return untyped.(v1.ClusterRoleInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1.ClusterRoleInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1.ClusterRoleInformer)
+	return inf, ok
+}
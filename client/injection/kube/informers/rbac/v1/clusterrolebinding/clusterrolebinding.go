@@ -50,3 +50,10 @@ func Get(ctx context.Context) v1.ClusterRoleBindingInformer {
 	}
 	return untyped.(v1.ClusterRoleBindingInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1.ClusterRoleBindingInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1.ClusterRoleBindingInformer)
+	return inf, ok
+}
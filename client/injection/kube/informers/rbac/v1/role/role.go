@@ -22,6 +22,7 @@ import (
 	context "context"
 
 	v1 "k8s.io/client-go/informers/rbac/v1"
+	rbacv1 "k8s.io/client-go/listers/rbac/v1"
 	factory "knative.dev/pkg/client/injection/kube/informers/factory"
 	controller "knative.dev/pkg/controller"
 	injection "knative.dev/pkg/injection"
@@ -50,3 +51,16 @@ func Get(ctx context.Context) v1.RoleInformer {
 	}
 	return untyped.(v1.RoleInformer)
 }
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx context.Context) (v1.RoleInformer, bool) {
+	inf, ok := ctx.Value(Key{}).(v1.RoleInformer)
+	return inf, ok
+}
+
+// GetNamespacedLister returns the lister of the typed informer in the
+// context, scoped to the given namespace.
+func GetNamespacedLister(ctx context.Context, namespace string) rbacv1.RoleNamespaceLister {
+	return Get(ctx).Lister().Roles(namespace)
+}
@@ -115,4 +115,11 @@ func Get(ctx {{.contextContext|raw}}) {{.informersSharedInformerFactory|raw}} {
 	}
 	return untyped.({{.informersSharedInformerFactory|raw}})
 }
+
+// TryGet extracts the InformerFactory from the context, reporting whether
+// it was present.
+func TryGet(ctx {{.contextContext|raw}}) ({{.informersSharedInformerFactory|raw}}, bool) {
+	f, ok := ctx.Value(Key{}).({{.informersSharedInformerFactory|raw}})
+	return f, ok
+}
 `
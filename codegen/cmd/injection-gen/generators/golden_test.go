@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// Run `go test ./codegen/cmd/injection-gen/generators -update` to regenerate
+// the golden files after intentionally changing a template.
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+const (
+	goldenClientSetPackage = "knative.dev/pkg/client/injection/kube/client"
+	goldenInformersPackage = "k8s.io/client-go/informers"
+)
+
+// goldenType is the representative type the generators are run against.
+var goldenType = &types.Type{
+	Name: types.Name{Package: "k8s.io/api/core/v1", Name: "Pod"},
+	Kind: types.Struct,
+}
+
+// generate runs g against t the way gengo does for a single file and
+// returns the gofmt-ed result.
+func generate(t *testing.T, pkg string, g generator.Generator, typ *types.Type) []byte {
+	t.Helper()
+
+	c := &generator.Context{Universe: types.Universe{}}
+	c.Namers = g.Namers(c)
+
+	var body bytes.Buffer
+	if err := g.Init(c, &body); err != nil {
+		t.Fatal("Init() =", err)
+	}
+	if err := g.GenerateType(c, typ, &body); err != nil {
+		t.Fatal("GenerateType() =", err)
+	}
+	if err := g.Finalize(c, &body); err != nil {
+		t.Fatal("Finalize() =", err)
+	}
+
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "package %s\n\nimport (\n", pkg)
+	for _, i := range g.Imports(c) {
+		fmt.Fprintf(&file, "\t%s\n", i)
+	}
+	fmt.Fprintf(&file, ")\n%s", body.Bytes())
+
+	out, err := format.Source(file.Bytes())
+	if err != nil {
+		t.Fatalf("format.Source() = %v\n%s", err, file.Bytes())
+	}
+	return out
+}
+
+// assertGolden compares got with testdata/<name>.golden, rewriting the file
+// instead when -update is passed.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	golden := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := ioutil.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal("Failed to update golden file:", err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal("Failed to load golden file:", err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("%s does not match the generated code, run with -update if this is intended (-want, +got) = %s", golden, diff)
+	}
+}
+
+func TestFactoryGolden(t *testing.T) {
+	const pkg = "knative.dev/pkg/client/injection/kube/informers/factory"
+	g := &factoryGenerator{
+		outputPackage:                pkg,
+		cachingClientSetPackage:      goldenClientSetPackage,
+		sharedInformerFactoryPackage: goldenInformersPackage,
+		imports:                      generator.NewImportTracker(),
+	}
+	assertGolden(t, "factory", generate(t, "factory", g, goldenType))
+}
+
+func TestInformerGolden(t *testing.T) {
+	tests := []struct {
+		name          string
+		typ           *types.Type
+		nonNamespaced bool
+	}{{
+		name: "informer",
+		typ:  goldenType,
+	}, {
+		name: "informer_nonnamespaced",
+		typ: &types.Type{
+			Name: types.Name{Package: "k8s.io/api/core/v1", Name: "Namespace"},
+			Kind: types.Struct,
+		},
+		nonNamespaced: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgName := strings.ToLower(test.typ.Name.Name)
+			g := &injectionGenerator{
+				outputPackage:               "knative.dev/pkg/client/injection/kube/informers/core/v1/" + pkgName,
+				groupVersion:                clientgentypes.GroupVersion{Group: "core", Version: "v1"},
+				groupGoName:                 "Core",
+				typeToGenerate:              test.typ,
+				imports:                     generator.NewImportTracker(),
+				typedInformerPackage:        "k8s.io/client-go/informers/core/v1",
+				groupInformerFactoryPackage: "knative.dev/pkg/client/injection/kube/informers/factory",
+				listerPkg:                   "k8s.io/client-go/listers/core/v1",
+				nonNamespaced:               test.nonNamespaced,
+			}
+			assertGolden(t, test.name, generate(t, pkgName, g, test.typ))
+		})
+	}
+}
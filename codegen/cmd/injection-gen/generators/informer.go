@@ -37,6 +37,8 @@ type injectionGenerator struct {
 	imports                     namer.ImportTracker
 	typedInformerPackage        string
 	groupInformerFactoryPackage string
+	listerPkg                   string
+	nonNamespaced               bool
 }
 
 var _ generator.Generator = (*injectionGenerator)(nil)
@@ -86,6 +88,8 @@ func (g *injectionGenerator) GenerateType(c *generator.Context, t *types.Type, w
 		"controllerInformer":        c.Universe.Type(types.Name{Package: "knative.dev/pkg/controller", Name: "Informer"}),
 		"informersTypedInformer":    c.Universe.Type(types.Name{Package: g.typedInformerPackage, Name: t.Name.Name + "Informer"}),
 		"factoryGet":                c.Universe.Type(types.Name{Package: g.groupInformerFactoryPackage, Name: "Get"}),
+		"nonNamespaced":             g.nonNamespaced,
+		"namespaceLister":           c.Universe.Type(types.Name{Package: g.listerPkg, Name: t.Name.Name + "NamespaceLister"}),
 		"loggingFromContext": c.Universe.Function(types.Name{
 			Package: "knative.dev/pkg/logging",
 			Name:    "FromContext",
@@ -124,4 +128,17 @@ func Get(ctx {{.contextContext|raw}}) {{.informersTypedInformer|raw}} {
 	}
 	return untyped.({{.informersTypedInformer|raw}})
 }
-`
+
+// TryGet extracts the typed informer from the context, reporting whether
+// it was present.
+func TryGet(ctx {{.contextContext|raw}}) ({{.informersTypedInformer|raw}}, bool) {
+	inf, ok := ctx.Value(Key{}).({{.informersTypedInformer|raw}})
+	return inf, ok
+}
+{{if not .nonNamespaced}}
+// GetNamespacedLister returns the lister of the typed informer in the
+// context, scoped to the given namespace.
+func GetNamespacedLister(ctx {{.contextContext|raw}}, namespace string) {{.namespaceLister|raw}} {
+	return Get(ctx).Lister().{{.type|publicPlural}}(namespace)
+}
+{{end}}`
@@ -400,6 +400,8 @@ func versionInformerPackages(basePackage string, groupPkgName string, gv clientg
 		t := t
 		packagePath := packagePath + "/" + strings.ToLower(t.Name.Name)
 		typedInformerPackage := typedInformerPackage(groupPkgName, gv, customArgs.ExternalVersionsInformersPackage)
+		listerPackagePath := filepath.Join(customArgs.ListersPackage, groupPkgName, strings.ToLower(gv.Version.NonEmpty()))
+		nonNamespaced := isNonNamespaced(extractCommentTags(t))
 
 		// Impl
 		vers = append(vers, &generator.DefaultPackage{
@@ -419,6 +421,8 @@ func versionInformerPackages(basePackage string, groupPkgName string, gv clientg
 					imports:                     generator.NewImportTracker(),
 					typedInformerPackage:        typedInformerPackage,
 					groupInformerFactoryPackage: factoryPackagePath,
+					listerPkg:                   listerPackagePath,
+					nonNamespaced:               nonNamespaced,
 				})
 				return generators
 			},
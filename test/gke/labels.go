@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gke
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// ProwJobLabel is the resource label holding the name of the Prow job
+	// that created the cluster.
+	ProwJobLabel = "prow-job"
+	// BuildNumberLabel is the resource label holding the build number of
+	// the Prow job that created the cluster.
+	BuildNumberLabel = "build-number"
+	// TTLLabel is the resource label holding how long the cluster is
+	// expected to live, e.g. 2h0m0s.
+	TTLLabel = "ttl"
+
+	// maxLabelLength is the maximum length of a GCP resource label value.
+	maxLabelLength = 63
+)
+
+// invalidLabelChars matches what may not appear in a GCP resource label value.
+var invalidLabelChars = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// ProwJobResourceLabels returns the resource labels attributing a cluster to
+// the Prow job creating it, read from the Prow environment variables. The
+// TTL label is only set if ttl is positive. It returns an error when not
+// running in Prow.
+func ProwJobResourceLabels(ttl time.Duration) (map[string]string, error) {
+	if !strings.EqualFold(os.Getenv("CI"), "true") {
+		return nil, errors.New("not running in Prow, CI is not set to true")
+	}
+	labels := map[string]string{
+		ProwJobLabel:     labelValue(os.Getenv("JOB_NAME")),
+		BuildNumberLabel: labelValue(os.Getenv("BUILD_ID")),
+	}
+	if ttl > 0 {
+		labels[TTLLabel] = labelValue(ttl.String())
+	}
+	return labels, nil
+}
+
+// labelValue turns s into a valid GCP resource label value, by lower casing
+// it, replacing invalid characters with dashes and truncating it.
+func labelValue(s string) string {
+	s = invalidLabelChars.ReplaceAllString(strings.ToLower(s), "-")
+	if len(s) > maxLabelLength {
+		s = s[:maxLabelLength]
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gke
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func setEnv(t *testing.T, env map[string]string) {
+	for k, v := range env {
+		old, ok := os.LookupEnv(k)
+		os.Setenv(k, v)
+		k := k
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(k, old)
+			} else {
+				os.Unsetenv(k)
+			}
+		})
+	}
+}
+
+func TestProwJobResourceLabels(t *testing.T) {
+	setEnv(t, map[string]string{
+		"CI":       "true",
+		"JOB_NAME": "ci-knative-serving-continuous_1.18",
+		"BUILD_ID": "1234567890",
+	})
+
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		want map[string]string
+	}{{
+		name: "without ttl",
+		want: map[string]string{
+			ProwJobLabel:     "ci-knative-serving-continuous_1-18",
+			BuildNumberLabel: "1234567890",
+		},
+	}, {
+		name: "with ttl",
+		ttl:  2 * time.Hour,
+		want: map[string]string{
+			ProwJobLabel:     "ci-knative-serving-continuous_1-18",
+			BuildNumberLabel: "1234567890",
+			TTLLabel:         "2h0m0s",
+		},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ProwJobResourceLabels(test.ttl)
+			if err != nil {
+				t.Fatal("ProwJobResourceLabels() =", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error("ProwJobResourceLabels (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestProwJobResourceLabelsNotInCI(t *testing.T) {
+	setEnv(t, map[string]string{"CI": "false"})
+	if _, err := ProwJobResourceLabels(time.Hour); err == nil {
+		t.Error("ProwJobResourceLabels() = nil, want an error outside of Prow")
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	if got, want := labelValue("Some.Job/Name"), "some-job-name"; got != want {
+		t.Errorf("labelValue() = %q, want: %q", got, want)
+	}
+	if got := labelValue(strings.Repeat("a", 100)); len(got) != maxLabelLength {
+		t.Errorf("len(labelValue()) = %d, want: %d", len(got), maxLabelLength)
+	}
+}
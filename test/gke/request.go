@@ -18,12 +18,19 @@ package gke
 
 import (
 	"errors"
+	"fmt"
+	"net"
+	"regexp"
 
 	container "google.golang.org/api/container/v1beta1"
 )
 
 const defaultGKEVersion = "latest"
 
+// gkeVersionRegex matches the cluster versions GKE accepts: "latest", "-" for
+// the default version, or a version such as "1.17", "1.17.9" or "1.17.9-gke.1504".
+var gkeVersionRegex = regexp.MustCompile(`^(latest|-|\d+\.\d+(\.\d+(-gke\.\d+)?)?)$`)
+
 // Request contains all settings collected for cluster creation
 type Request struct {
 	// Project: name of the gcloud project for the cluster
@@ -63,6 +70,30 @@ type Request struct {
 
 	// ServiceAccount: service account that will be used on this cluster
 	ServiceAccount string
+
+	// ResourceLabels: GCP resource labels to attach to the cluster, e.g. the
+	// ones returned by ProwJobResourceLabels
+	ResourceLabels map[string]string
+
+	// Network: VPC network the cluster is connected to, default to be the
+	// project's default network if not provided. Must be provided together
+	// with Subnetwork
+	Network string
+
+	// Subnetwork: subnetwork of Network the cluster is connected to
+	Subnetwork string
+
+	// EnablePrivateNodes: whether to create a private cluster, whose nodes
+	// only have internal IP addresses. Requires MasterIPv4CIDR
+	EnablePrivateNodes bool
+
+	// EnablePrivateEndpoint: whether the cluster master is only reachable
+	// through its internal IP address. Requires EnablePrivateNodes
+	EnablePrivateEndpoint bool
+
+	// MasterIPv4CIDR: the /28 internal IP range of the master of a private
+	// cluster, e.g. 172.16.0.0/28
+	MasterIPv4CIDR string
 }
 
 // DeepCopy will make a deepcopy of the request struct.
@@ -80,7 +111,24 @@ func (r *Request) DeepCopy() *Request {
 		Addons:                 r.Addons,
 		EnableWorkloadIdentity: r.EnableWorkloadIdentity,
 		ServiceAccount:         r.ServiceAccount,
+		ResourceLabels:         copyLabels(r.ResourceLabels),
+		Network:                r.Network,
+		Subnetwork:             r.Subnetwork,
+		EnablePrivateNodes:     r.EnablePrivateNodes,
+		EnablePrivateEndpoint:  r.EnablePrivateEndpoint,
+		MasterIPv4CIDR:         r.MasterIPv4CIDR,
+	}
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	c := make(map[string]string, len(labels))
+	for k, v := range labels {
+		c[k] = v
 	}
+	return c
 }
 
 // NewCreateClusterRequest returns a new CreateClusterRequest that can be used in gcloud SDK.
@@ -103,6 +151,15 @@ func NewCreateClusterRequest(request *Request) (*container.CreateClusterRequest,
 	if request.GKEVersion != "" && request.ReleaseChannel != "" {
 		return nil, errors.New("can only specify one of GKE version or release channel (not both)")
 	}
+	if (request.Network == "") != (request.Subnetwork == "") {
+		return nil, errors.New("network and subnetwork must be specified together")
+	}
+	if err := validatePrivateCluster(request); err != nil {
+		return nil, err
+	}
+	if request.GKEVersion != "" && !gkeVersionRegex.MatchString(request.GKEVersion) {
+		return nil, fmt.Errorf("invalid GKE version %q, expected e.g. 1.17 or 1.17.9-gke.1504", request.GKEVersion)
+	}
 
 	ccr := &container.CreateClusterRequest{
 		Cluster: &container.Cluster{
@@ -138,7 +195,11 @@ func NewCreateClusterRequest(request *Request) (*container.CreateClusterRequest,
 			// later on retrieved for setting up cluster roles. Use the
 			// default username from gcloud command, the password will be
 			// automatically generated by GKE SDK
-			MasterAuth: &container.MasterAuth{Username: "admin"},
+			MasterAuth:     &container.MasterAuth{Username: "admin"},
+			ResourceLabels: request.ResourceLabels,
+			// Empty means the project's default network.
+			Network:    request.Network,
+			Subnetwork: request.Subnetwork,
 		},
 	}
 	if request.EnableWorkloadIdentity {
@@ -149,6 +210,15 @@ func NewCreateClusterRequest(request *Request) (*container.CreateClusterRequest,
 			IdentityNamespace: request.Project + ".svc.id.goog",
 		}
 	}
+	if request.EnablePrivateNodes {
+		ccr.Cluster.PrivateClusterConfig = &container.PrivateClusterConfig{
+			EnablePrivateNodes:    true,
+			EnablePrivateEndpoint: request.EnablePrivateEndpoint,
+			MasterIpv4CidrBlock:   request.MasterIPv4CIDR,
+		}
+		// Private clusters have to be VPC-native, i.e. use alias IPs.
+		ccr.Cluster.IpAllocationPolicy = &container.IPAllocationPolicy{UseIpAliases: true}
+	}
 	if request.ServiceAccount != "" {
 		// The Google Cloud Platform Service Account to be used by the node VMs.
 		// If a service account is specified, the cloud-platform and userinfo.email scopes are used.
@@ -168,3 +238,25 @@ func NewCreateClusterRequest(request *Request) (*container.CreateClusterRequest,
 	}
 	return ccr, nil
 }
+
+// validatePrivateCluster checks that the private cluster settings of request
+// are consistent.
+func validatePrivateCluster(request *Request) error {
+	if !request.EnablePrivateNodes {
+		if request.EnablePrivateEndpoint || request.MasterIPv4CIDR != "" {
+			return errors.New("private endpoint and master IPv4 CIDR require private nodes")
+		}
+		return nil
+	}
+	if request.MasterIPv4CIDR == "" {
+		return errors.New("master IPv4 CIDR cannot be empty for private nodes")
+	}
+	ip, ipNet, err := net.ParseCIDR(request.MasterIPv4CIDR)
+	if err != nil || ip.To4() == nil {
+		return fmt.Errorf("invalid master IPv4 CIDR %q", request.MasterIPv4CIDR)
+	}
+	if ones, _ := ipNet.Mask.Size(); ones != 28 {
+		return fmt.Errorf("master IPv4 CIDR %q must be a /28 range", request.MasterIPv4CIDR)
+	}
+	return nil
+}
@@ -16,7 +16,12 @@ limitations under the License.
 
 package gke
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	container "google.golang.org/api/container/v1beta1"
+)
 
 func TestNewCreateClusterRequest(t *testing.T) {
 	datas := []struct {
@@ -60,7 +65,7 @@ func TestNewCreateClusterRequest(t *testing.T) {
 			req: &Request{
 				Project:        "project-b",
 				ClusterName:    "name-b",
-				GKEVersion:     "1-2-3",
+				GKEVersion:     "1.2.3",
 				MinNodes:       10,
 				MaxNodes:       10,
 				NodeType:       "n1-standard-8",
@@ -72,7 +77,7 @@ func TestNewCreateClusterRequest(t *testing.T) {
 		{
 			req: &Request{
 				Project:    "project-c",
-				GKEVersion: "1-2-3",
+				GKEVersion: "1.2.3",
 				MinNodes:   1,
 				MaxNodes:   1,
 				NodeType:   "n1-standard-4",
@@ -81,7 +86,7 @@ func TestNewCreateClusterRequest(t *testing.T) {
 		}, {
 			req: &Request{
 				Project:     "project-d",
-				GKEVersion:  "1-2-3",
+				GKEVersion:  "1.2.3",
 				ClusterName: "name-d",
 				MinNodes:    0,
 				MaxNodes:    1,
@@ -91,7 +96,7 @@ func TestNewCreateClusterRequest(t *testing.T) {
 		}, {
 			req: &Request{
 				Project:     "project-e",
-				GKEVersion:  "1-2-3",
+				GKEVersion:  "1.2.3",
 				ClusterName: "name-e",
 				MinNodes:    10,
 				MaxNodes:    1,
@@ -101,7 +106,7 @@ func TestNewCreateClusterRequest(t *testing.T) {
 		}, {
 			req: &Request{
 				Project:     "project-f",
-				GKEVersion:  "1-2-3",
+				GKEVersion:  "1.2.3",
 				ClusterName: "name-f",
 				MinNodes:    1,
 				MaxNodes:    1,
@@ -110,7 +115,7 @@ func TestNewCreateClusterRequest(t *testing.T) {
 		}, {
 			req: &Request{
 				Project:     "project-d",
-				GKEVersion:  "1-2-3",
+				GKEVersion:  "1.2.3",
 				ClusterName: "name-d",
 				MinNodes:    0,
 				MaxNodes:    1,
@@ -120,7 +125,7 @@ func TestNewCreateClusterRequest(t *testing.T) {
 		}, {
 			req: &Request{
 				Project:     "project-e",
-				GKEVersion:  "1-2-3",
+				GKEVersion:  "1.2.3",
 				ClusterName: "name-e",
 				MinNodes:    10,
 				MaxNodes:    1,
@@ -130,7 +135,7 @@ func TestNewCreateClusterRequest(t *testing.T) {
 		}, {
 			req: &Request{
 				Project:     "project-f",
-				GKEVersion:  "1-2-3",
+				GKEVersion:  "1.2.3",
 				ClusterName: "name-f",
 				MinNodes:    1,
 				MaxNodes:    1,
@@ -139,7 +144,7 @@ func TestNewCreateClusterRequest(t *testing.T) {
 		}, {
 			req: &Request{
 				Project:                "project-g",
-				GKEVersion:             "1-2-3",
+				GKEVersion:             "1.2.3",
 				ClusterName:            "name-g",
 				MinNodes:               1,
 				MaxNodes:               1,
@@ -149,7 +154,7 @@ func TestNewCreateClusterRequest(t *testing.T) {
 			errorExpected: false,
 		}, {
 			req: &Request{
-				GKEVersion:             "1-2-3",
+				GKEVersion:             "1.2.3",
 				ClusterName:            "name-h",
 				MinNodes:               3,
 				MaxNodes:               3,
@@ -160,7 +165,7 @@ func TestNewCreateClusterRequest(t *testing.T) {
 		}, {
 			req: &Request{
 				Project:        "project-i",
-				GKEVersion:     "1-2-3",
+				GKEVersion:     "1.2.3",
 				ClusterName:    "name-i",
 				MinNodes:       3,
 				MaxNodes:       3,
@@ -185,3 +190,246 @@ func TestNewCreateClusterRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestNewCreateClusterRequestVersion(t *testing.T) {
+	datas := []struct {
+		name          string
+		gkeVersion    string
+		wantVersion   string
+		errorExpected bool
+	}{{
+		name:        "unset",
+		wantVersion: defaultGKEVersion,
+	}, {
+		name:        "minor version",
+		gkeVersion:  "1.17",
+		wantVersion: "1.17",
+	}, {
+		name:        "patch version",
+		gkeVersion:  "1.17.9",
+		wantVersion: "1.17.9",
+	}, {
+		name:        "gke version",
+		gkeVersion:  "1.17.9-gke.1504",
+		wantVersion: "1.17.9-gke.1504",
+	}, {
+		name:        "latest",
+		gkeVersion:  "latest",
+		wantVersion: "latest",
+	}, {
+		name:          "dashes",
+		gkeVersion:    "1-2-3",
+		errorExpected: true,
+	}, {
+		name:          "leading v",
+		gkeVersion:    "v1.17.9",
+		errorExpected: true,
+	}, {
+		name:          "trailing garbage",
+		gkeVersion:    "1.17.9-gke",
+		errorExpected: true,
+	}}
+	for _, data := range datas {
+		t.Run(data.name, func(t *testing.T) {
+			createReq, err := NewCreateClusterRequest(&Request{
+				ClusterName: "name-a",
+				MinNodes:    1,
+				MaxNodes:    1,
+				NodeType:    "n1-standard-4",
+				GKEVersion:  data.gkeVersion,
+			})
+			if data.errorExpected {
+				if err == nil {
+					t.Errorf("Expected error for GKE version %q, but got nil", data.gkeVersion)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error for GKE version %q, but got '%v'", data.gkeVersion, err)
+			}
+			if got := createReq.Cluster.InitialClusterVersion; got != data.wantVersion {
+				t.Errorf("InitialClusterVersion = %q, want: %q", got, data.wantVersion)
+			}
+		})
+	}
+}
+
+func TestNewCreateClusterRequestResourceLabels(t *testing.T) {
+	labels := map[string]string{ProwJobLabel: "job", BuildNumberLabel: "42"}
+	req := &Request{
+		ClusterName:    "name-a",
+		MinNodes:       1,
+		MaxNodes:       1,
+		NodeType:       "n1-standard-4",
+		ResourceLabels: labels,
+	}
+	createReq, err := NewCreateClusterRequest(req.DeepCopy())
+	if err != nil {
+		t.Fatal("NewCreateClusterRequest() =", err)
+	}
+	if got := createReq.Cluster.ResourceLabels; !reflect.DeepEqual(got, labels) {
+		t.Errorf("ResourceLabels = %v, want: %v", got, labels)
+	}
+}
+
+func TestNewCreateClusterRequestNetwork(t *testing.T) {
+	datas := []struct {
+		name          string
+		network       string
+		subnetwork    string
+		errorExpected bool
+	}{{
+		name: "default network",
+	}, {
+		name:       "network and subnetwork",
+		network:    "test-vpc",
+		subnetwork: "test-subnet",
+	}, {
+		name:          "network only",
+		network:       "test-vpc",
+		errorExpected: true,
+	}, {
+		name:          "subnetwork only",
+		subnetwork:    "test-subnet",
+		errorExpected: true,
+	}}
+	for _, data := range datas {
+		t.Run(data.name, func(t *testing.T) {
+			req := &Request{
+				ClusterName: "name-a",
+				MinNodes:    1,
+				MaxNodes:    1,
+				NodeType:    "n1-standard-4",
+				Network:     data.network,
+				Subnetwork:  data.subnetwork,
+			}
+			createReq, err := NewCreateClusterRequest(req.DeepCopy())
+			if data.errorExpected {
+				if err == nil {
+					t.Error("Expected error for mismatched network and subnetwork, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("NewCreateClusterRequest() =", err)
+			}
+			if got := createReq.Cluster.Network; got != data.network {
+				t.Errorf("Network = %q, want: %q", got, data.network)
+			}
+			if got := createReq.Cluster.Subnetwork; got != data.subnetwork {
+				t.Errorf("Subnetwork = %q, want: %q", got, data.subnetwork)
+			}
+		})
+	}
+}
+
+func TestNewCreateClusterRequestPrivateCluster(t *testing.T) {
+	datas := []struct {
+		name                  string
+		enablePrivateNodes    bool
+		enablePrivateEndpoint bool
+		masterIPv4CIDR        string
+		want                  *container.PrivateClusterConfig
+		errorExpected         bool
+	}{{
+		name: "public cluster",
+	}, {
+		name:               "private nodes",
+		enablePrivateNodes: true,
+		masterIPv4CIDR:     "172.16.0.0/28",
+		want: &container.PrivateClusterConfig{
+			EnablePrivateNodes:  true,
+			MasterIpv4CidrBlock: "172.16.0.0/28",
+		},
+	}, {
+		name:                  "private endpoint",
+		enablePrivateNodes:    true,
+		enablePrivateEndpoint: true,
+		masterIPv4CIDR:        "172.16.0.16/28",
+		want: &container.PrivateClusterConfig{
+			EnablePrivateNodes:    true,
+			EnablePrivateEndpoint: true,
+			MasterIpv4CidrBlock:   "172.16.0.16/28",
+		},
+	}, {
+		name:                  "private endpoint without private nodes",
+		enablePrivateEndpoint: true,
+		errorExpected:         true,
+	}, {
+		name:           "master CIDR without private nodes",
+		masterIPv4CIDR: "172.16.0.0/28",
+		errorExpected:  true,
+	}, {
+		name:               "private nodes without master CIDR",
+		enablePrivateNodes: true,
+		errorExpected:      true,
+	}, {
+		name:               "invalid master CIDR",
+		enablePrivateNodes: true,
+		masterIPv4CIDR:     "172.16.0.0",
+		errorExpected:      true,
+	}, {
+		name:               "master CIDR too large",
+		enablePrivateNodes: true,
+		masterIPv4CIDR:     "172.16.0.0/24",
+		errorExpected:      true,
+	}, {
+		name:               "IPv6 master CIDR",
+		enablePrivateNodes: true,
+		masterIPv4CIDR:     "fd00::/28",
+		errorExpected:      true,
+	}}
+	for _, data := range datas {
+		t.Run(data.name, func(t *testing.T) {
+			req := &Request{
+				ClusterName:           "name-a",
+				MinNodes:              1,
+				MaxNodes:              1,
+				NodeType:              "n1-standard-4",
+				EnablePrivateNodes:    data.enablePrivateNodes,
+				EnablePrivateEndpoint: data.enablePrivateEndpoint,
+				MasterIPv4CIDR:        data.masterIPv4CIDR,
+			}
+			createReq, err := NewCreateClusterRequest(req.DeepCopy())
+			if data.errorExpected {
+				if err == nil {
+					t.Error("Expected error for the private cluster settings, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("NewCreateClusterRequest() =", err)
+			}
+			if got := createReq.Cluster.PrivateClusterConfig; !reflect.DeepEqual(got, data.want) {
+				t.Errorf("PrivateClusterConfig = %+v, want: %+v", got, data.want)
+			}
+			if gotIPAlias := createReq.Cluster.IpAllocationPolicy != nil && createReq.Cluster.IpAllocationPolicy.UseIpAliases; gotIPAlias != data.enablePrivateNodes {
+				t.Errorf("UseIpAliases = %v, want: %v", gotIPAlias, data.enablePrivateNodes)
+			}
+		})
+	}
+}
+
+func TestNewCreateClusterRequestWorkloadIdentity(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		req := &Request{
+			Project:                "project-a",
+			ClusterName:            "name-a",
+			MinNodes:               1,
+			MaxNodes:               1,
+			NodeType:               "n1-standard-4",
+			EnableWorkloadIdentity: enabled,
+		}
+		createReq, err := NewCreateClusterRequest(req)
+		if err != nil {
+			t.Fatal("NewCreateClusterRequest() =", err)
+		}
+		var want *container.WorkloadIdentityConfig
+		if enabled {
+			want = &container.WorkloadIdentityConfig{IdentityNamespace: "project-a.svc.id.goog"}
+		}
+		if got := createReq.Cluster.WorkloadIdentityConfig; !reflect.DeepEqual(got, want) {
+			t.Errorf("EnableWorkloadIdentity=%v: WorkloadIdentityConfig = %+v, want: %+v", enabled, got, want)
+		}
+	}
+}
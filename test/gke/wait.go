@@ -17,6 +17,7 @@ limitations under the License.
 package gke
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -30,6 +31,14 @@ var (
 	deletionTimeout = 10 * time.Minute
 )
 
+// The interval between two polls of an operation starts at
+// initialPollInterval and doubles after each poll until it reaches
+// maxPollInterval, so that long running operations don't hammer the API.
+var (
+	initialPollInterval = 500 * time.Millisecond
+	maxPollInterval     = 30 * time.Second
+)
+
 const (
 	pendingStatus = "PENDING"
 	runningStatus = "RUNNING"
@@ -37,20 +46,29 @@ const (
 )
 
 // Wait depends on unique opName(operation ID created by cloud), and waits until
-// it's done
+// it's done or the given wait duration has passed.
 func Wait(gsc SDKOperations, project, region, zone, opName string, wait time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), wait)
+	defer cancel()
+	return WaitContext(ctx, gsc, project, region, zone, opName)
+}
+
+// WaitContext depends on unique opName(operation ID created by cloud), and
+// waits until it's done or ctx is done. The operation is polled with an
+// exponential backoff.
+func WaitContext(ctx context.Context, gsc SDKOperations, project, region, zone, opName string) error {
 	var op *container.Operation
 	var err error
 
-	timeout := time.After(wait)
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
+	interval := initialPollInterval
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
 	for {
 		select {
 		// Got a timeout! fail with a timeout error
-		case <-timeout:
+		case <-ctx.Done():
 			return errors.New("timed out waiting")
-		case <-ticker.C:
+		case <-timer.C:
 			// Retry 3 times in case of weird network error, or rate limiting
 			for r, w := 0, 50*time.Microsecond; r < 3; r, w = r+1, w*2 {
 				op, err = gsc.GetOperation(project, region, zone, opName)
@@ -72,6 +90,16 @@ func Wait(gsc SDKOperations, project, region, zone, opName string, wait time.Dur
 			if err != nil {
 				return err
 			}
+			interval = nextPollInterval(interval)
+			timer.Reset(interval)
 		}
 	}
 }
+
+// nextPollInterval returns the interval to wait after one of length d.
+func nextPollInterval(d time.Duration) time.Duration {
+	if d *= 2; d > maxPollInterval {
+		return maxPollInterval
+	}
+	return d
+}
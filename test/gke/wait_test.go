@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gke
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	container "google.golang.org/api/container/v1beta1"
+)
+
+// pollingOperations reports an operation as running until it was polled
+// doneAfter times.
+type pollingOperations struct {
+	SDKOperations
+	doneAfter int
+	polls     int
+	err       error
+}
+
+func (p *pollingOperations) GetOperation(project, region, zone, opName string) (*container.Operation, error) {
+	p.polls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.polls >= p.doneAfter {
+		return &container.Operation{Name: opName, Status: doneStatus}, nil
+	}
+	return &container.Operation{Name: opName, Status: runningStatus}, nil
+}
+
+func withFastPolling(t *testing.T) {
+	oldInitial, oldMax := initialPollInterval, maxPollInterval
+	initialPollInterval, maxPollInterval = time.Millisecond, 4*time.Millisecond
+	t.Cleanup(func() {
+		initialPollInterval, maxPollInterval = oldInitial, oldMax
+	})
+}
+
+func TestWait(t *testing.T) {
+	withFastPolling(t)
+
+	ops := &pollingOperations{doneAfter: 5}
+	if err := Wait(ops, "project", "region", "", "op", time.Minute); err != nil {
+		t.Fatal("Wait() =", err)
+	}
+	if ops.polls != ops.doneAfter {
+		t.Errorf("GetOperation was called %d times, want: %d", ops.polls, ops.doneAfter)
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	withFastPolling(t)
+
+	ops := &pollingOperations{doneAfter: 1 << 30}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := WaitContext(ctx, ops, "project", "region", "", "op"); err == nil {
+		t.Fatal("WaitContext() = nil, want a timeout error")
+	}
+	if ops.polls == 0 {
+		t.Error("GetOperation was never called")
+	}
+}
+
+func TestWaitError(t *testing.T) {
+	withFastPolling(t)
+
+	ops := &pollingOperations{err: errors.New("boom")}
+	if err := Wait(ops, "project", "region", "", "op", time.Minute); err == nil {
+		t.Fatal("Wait() = nil, want an error")
+	}
+	// The failing call is retried before giving up.
+	if ops.polls != 3 {
+		t.Errorf("GetOperation was called %d times, want: 3", ops.polls)
+	}
+}
+
+func TestNextPollInterval(t *testing.T) {
+	withFastPolling(t)
+
+	want := []time.Duration{2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond}
+	d := initialPollInterval
+	for i, w := range want {
+		d = nextPollInterval(d)
+		if d != w {
+			t.Errorf("interval after %d polls = %v, want: %v", i+1, d, w)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{{
+		name: "valid",
+		data: `{"apiVersion":"pkg.knative.dev/v2","kind":"InnerDefaultResource","metadata":{"name":"foo"},"spec":{"fieldWithDefault":"bar","subfields":{"string":"baz"}}}`,
+	}, {
+		name:    "unknown top-level field",
+		data:    `{"metadata":{"name":"foo"},"sepc":{}}`,
+		wantErr: `unknown field "sepc"`,
+	}, {
+		name:    "unknown nested field",
+		data:    `{"metadata":{"name":"foo"},"spec":{"fieldWithDefualt":"bar"}}`,
+		wantErr: `unknown field "fieldWithDefualt"`,
+	}, {
+		name:    "trailing data",
+		data:    `{"metadata":{"name":"foo"}} {}`,
+		wantErr: "unexpected data after the JSON document",
+	}, {
+		name:    "malformed",
+		data:    `{"metadata":`,
+		wantErr: "unexpected EOF",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &InnerDefaultResource{}
+			err := DecodeStrict([]byte(test.data), r)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatal("DecodeStrict() =", err)
+				}
+				if r.Name != "foo" || r.Spec.FieldWithDefault != "bar" || r.Spec.SubFields.DeprecatedString != "baz" {
+					t.Errorf("DecodeStrict() decoded %#v", r)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("DecodeStrict() = %v, want error containing %q", err, test.wantErr)
+			}
+		})
+	}
+}